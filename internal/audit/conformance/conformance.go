@@ -0,0 +1,131 @@
+// Package conformance verifies that an implementation of audit.Repository behaves like every
+// other backend.
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	"tour-le-shit-go/internal/audit/model"
+)
+
+// Run runs the suite against the repositories returned by newRepository.
+func Run(t *testing.T, newRepository func(t *testing.T) audit.Repository) {
+	t.Helper()
+
+	ctx := context.Background()
+	day := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("empty repository has no entries", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+
+		entries, err := r.GetEntries(ctx, model.Filter{})
+		if err != nil || len(entries) != 0 {
+			t.Errorf("expected %d entries and no error got %d and %v", 0, len(entries), err)
+		}
+	})
+	t.Run("added entry is returned with every field", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		added := model.Entry{
+			Id:       "1",
+			Entity:   model.EntityScore,
+			EntityId: "round",
+			Action:   model.ActionUpdate,
+			Actor:    "alice",
+			At:       day,
+			Before:   `{"points":20}`,
+			After:    `{"points":30}`,
+		}
+		addEntries(t, r, added)
+
+		entries, err := r.GetEntries(ctx, model.Filter{})
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("expected %d entries and no error got %d and %v", 1, len(entries), err)
+		}
+
+		got := entries[0]
+		if got.Id != added.Id || got.Entity != added.Entity || got.EntityId != added.EntityId || got.Action != added.Action ||
+			got.Actor != added.Actor || !got.At.Equal(added.At) || got.Before != added.Before || got.After != added.After {
+			t.Errorf("expected %v got %v", added, got)
+		}
+	})
+	t.Run("entries are returned in the order they were added", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		addEntries(t, r,
+			model.Entry{Id: "b", Entity: model.EntityPlayer, EntityId: "1", Action: model.ActionCreate, At: day},
+			model.Entry{Id: "a", Entity: model.EntityPlayer, EntityId: "1", Action: model.ActionUpdate, At: day},
+			model.Entry{Id: "c", Entity: model.EntityPlayer, EntityId: "1", Action: model.ActionDelete, At: day.Add(time.Second)},
+		)
+
+		entries, _ := r.GetEntries(ctx, model.Filter{})
+		if len(entries) != 3 || entries[0].Id != "b" || entries[1].Id != "a" || entries[2].Id != "c" {
+			t.Errorf("expected b, a and c got %v", entries)
+		}
+	})
+	t.Run("entries are filtered by entity, actor and time", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		addEntries(t, r,
+			model.Entry{Id: "1", Entity: model.EntityPlayer, EntityId: "1", Action: model.ActionCreate, Actor: "alice", At: day.Add(-24 * time.Hour)},
+			model.Entry{Id: "2", Entity: model.EntityScore, EntityId: "1", Action: model.ActionCreate, Actor: "alice", At: day},
+			model.Entry{Id: "3", Entity: model.EntityScore, EntityId: "2", Action: model.ActionCreate, Actor: "bob", At: day},
+			model.Entry{Id: "4", Entity: model.EntityScore, EntityId: "1", Action: model.ActionDelete, Actor: "alice", At: day.Add(24 * time.Hour)},
+		)
+
+		tests := []struct {
+			name     string
+			filter   model.Filter
+			expected []string
+		}{
+			{"entity", model.Filter{Entity: model.EntityScore}, []string{"2", "3", "4"}},
+			{"actor", model.Filter{Actor: "alice"}, []string{"1", "2", "4"}},
+			{"from", model.Filter{From: day}, []string{"2", "3", "4"}},
+			{"until", model.Filter{Until: day.Add(24 * time.Hour)}, []string{"1", "2", "3"}},
+			{"everything", model.Filter{Entity: model.EntityScore, Actor: "alice", From: day, Until: day.Add(time.Hour)}, []string{"2"}},
+		}
+
+		for _, tt := range tests {
+			entries, err := r.GetEntries(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			if !hasIds(entries, tt.expected) {
+				t.Errorf("expected %v filtering by %s got %v", tt.expected, tt.name, entries)
+			}
+		}
+	})
+}
+
+func addEntries(t *testing.T, r audit.Repository, entries ...model.Entry) {
+	t.Helper()
+
+	for _, e := range entries {
+		err := r.AddEntry(context.Background(), e)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+	}
+}
+
+func hasIds(entries []model.Entry, ids []string) bool {
+	if len(entries) != len(ids) {
+		return false
+	}
+
+	for i, e := range entries {
+		if e.Id != ids[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/ierrors"
+)
+
+const InsertEntryQuery = `
+	INSERT INTO audit_entry (id, entity, entity_id, action, actor, at, before_value, after_value)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+`
+
+const GetEntriesQuery = `
+	SELECT id, entity, entity_id, action, actor, at, before_value, after_value FROM audit_entry
+	WHERE ($1 = '' OR entity = $1) AND ($2 = '' OR actor = $2) AND at >= $3 AND at < $4
+	ORDER BY at, seq;
+`
+
+// PostgresRepository the queries are kept portable so the repository also serves the SQLite mode.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) AddEntry(ctx context.Context, e model.Entry) error {
+	_, err := r.db.ExecContext(ctx, InsertEntryQuery, e.Id, e.Entity, e.EntityId, e.Action, e.Actor, e.At.UTC(), e.Before, e.After)
+	if err != nil {
+		return ierrors.Database("error executing insert audit entry query", err)
+	}
+
+	return nil
+}
+
+// GetEntries returns the matching entries in the order they were added.
+func (r *PostgresRepository) GetEntries(ctx context.Context, filter model.Filter) ([]model.Entry, error) {
+	from, until := bounds(filter)
+
+	rows, err := r.db.QueryContext(ctx, GetEntriesQuery, filter.Entity, filter.Actor, from, until)
+	if err != nil {
+		return nil, ierrors.Database("error fetching audit entries", err)
+	}
+
+	defer rows.Close()
+
+	entries := make([]model.Entry, 0)
+
+	for rows.Next() {
+		var e model.Entry
+
+		err = rows.Scan(&e.Id, &e.Entity, &e.EntityId, &e.Action, &e.Actor, &e.At, &e.Before, &e.After)
+		if err != nil {
+			return nil, ierrors.Database("error scanning audit entries", err)
+		}
+
+		e.At = e.At.UTC()
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading audit entries", err)
+	}
+
+	return entries, nil
+}
+
+// bounds replaces the open ends of the filter with times before and after every entry.
+func bounds(filter model.Filter) (time.Time, time.Time) {
+	from, until := filter.From.UTC(), filter.Until.UTC()
+
+	if filter.From.IsZero() {
+		from = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if filter.Until.IsZero() {
+		until = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	return from, until
+}
@@ -0,0 +1,29 @@
+package db_test
+
+import (
+	"testing"
+	"tour-le-shit-go/internal/audit"
+	"tour-le-shit-go/internal/audit/conformance"
+	"tour-le-shit-go/internal/audit/db"
+	"tour-le-shit-go/internal/dbtest"
+)
+
+func TestSqliteConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) audit.Repository {
+		t.Helper()
+
+		return db.NewRepository(dbtest.Sqlite(t))
+	})
+}
+
+func TestPostgresConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) audit.Repository {
+		t.Helper()
+
+		return db.NewRepository(dbtest.Postgres(t))
+	})
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/utils"
+)
+
+// MockedRepository keeps the audit log in memory and is safe for concurrent use.
+type MockedRepository struct {
+	mu      sync.RWMutex
+	entries []model.Entry
+}
+
+func NewRepository(entries []model.Entry) *MockedRepository {
+	return &MockedRepository{entries: entries}
+}
+
+func (r *MockedRepository) AddEntry(_ context.Context, entry model.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+// GetEntries returns the matching entries in the order they were added.
+func (r *MockedRepository) GetEntries(_ context.Context, filter model.Filter) ([]model.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]model.Entry, 0)
+
+	for _, e := range r.entries {
+		if filter.Matches(e) {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries, nil
+}
+
+// Load replaces the audit log with the content of the JSON file at path.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []model.Entry
+	if err := utils.ReadJson(path, &entries); err != nil {
+		return err
+	}
+
+	if entries != nil {
+		r.entries = entries
+	}
+
+	return nil
+}
+
+// Save writes the audit log to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, r.entries)
+}
@@ -0,0 +1,52 @@
+package mock_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	"tour-le-shit-go/internal/audit/conformance"
+	"tour-le-shit-go/internal/audit/mock"
+	"tour-le-shit-go/internal/audit/model"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) audit.Repository {
+		t.Helper()
+
+		return mock.NewRepository([]model.Entry{})
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path := filepath.Join(t.TempDir(), "audit.json")
+
+	saved := mock.NewRepository([]model.Entry{})
+	_ = saved.AddEntry(context.Background(), model.Entry{Id: "1", Entity: model.EntityScore, EntityId: "round", Action: model.ActionDelete, At: time.Now()})
+
+	err := saved.Save(path)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	loaded := mock.NewRepository([]model.Entry{})
+
+	// act
+	err = loaded.Load(path)
+
+	// assert
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	entries, _ := loaded.GetEntries(context.Background(), model.Filter{})
+	if len(entries) != 1 || entries[0].EntityId != "round" {
+		t.Errorf("expected the deleted round got %v", entries)
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// Entities of the audit log.
+const (
+	EntityPlayer = "player"
+	EntityScore  = "score"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionDelete    = "delete"
+	ActionRestore   = "restore"
+	ActionArchive   = "archive"
+	ActionUnarchive = "unarchive"
+	ActionFailed    = "failed"
+)
+
+// Entry a change of an entity. Before and After hold the entity as JSON, Before is empty for
+// created entities and After for deleted ones. A failed entry follows an entry whose change could
+// not be made and swaps its Before and After. Actor is the username of the user making the
+// change, empty when the server runs without authentication.
+type Entry struct {
+	Id       string
+	Entity   string
+	EntityId string
+	Action   string
+	Actor    string
+	At       time.Time
+	Before   string
+	After    string
+}
+
+// Filter the entries matching every given field, the zero value of a field matches any entry.
+// From is inclusive and Until exclusive.
+type Filter struct {
+	Entity string
+	Actor  string
+	From   time.Time
+	Until  time.Time
+}
+
+// Matches tells if the entry is selected by the filter.
+func (f Filter) Matches(e Entry) bool {
+	if f.Entity != "" && f.Entity != e.Entity {
+		return false
+	}
+
+	if f.Actor != "" && f.Actor != e.Actor {
+		return false
+	}
+
+	if !f.From.IsZero() && e.At.Before(f.From) {
+		return false
+	}
+
+	return f.Until.IsZero() || e.At.Before(f.Until)
+}
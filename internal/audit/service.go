@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/policy"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// Repository entries are only ever added, never changed or removed.
+type Repository interface {
+	AddEntry(ctx context.Context, entry model.Entry) error
+	GetEntries(ctx context.Context, filter model.Filter) ([]model.Entry, error)
+}
+
+type Service interface {
+	Record(ctx context.Context, entity, entityId, action string, before, after any, change func() error) error
+	GetEntries(ctx context.Context, filter model.Filter) ([]model.Entry, error)
+}
+
+// Representation converts an entity to what the API returns for it, which is what the audit
+// log stores.
+type Representation func(v any) (any, error)
+
+// Represent returns the representation converting values of type T with f.
+func Represent[T, R any](f func(T) R) Representation {
+	return func(v any) (any, error) {
+		t, ok := v.(T)
+		if !ok {
+			return nil, fmt.Errorf("can not represent %T in the audit log", v)
+		}
+
+		return f(t), nil
+	}
+}
+
+type service struct {
+	r               Repository
+	clock           utils.Clock
+	representations map[string]Representation
+}
+
+// NewService returns a service storing every entity with the representation of its kind.
+func NewService(r Repository, clock utils.Clock, representations map[string]Representation) Service {
+	return &service{r: r, clock: clock, representations: representations}
+}
+
+// Record adds an entry made by the user of ctx and then makes the change. The change is not made
+// when the entry can not be added, when the change fails a failed entry reverting it is added.
+// Before is nil for created entities and after is nil for deleted ones.
+func (s *service) Record(ctx context.Context, entity, entityId, action string, before, after any, change func() error) error {
+	entry := model.Entry{
+		Id:       uuid.New().String(),
+		Entity:   entity,
+		EntityId: entityId,
+		Action:   action,
+		At:       s.clock.Now().UTC(),
+	}
+
+	if user := policy.UserFrom(ctx); user != nil {
+		entry.Actor = user.Username
+	}
+
+	var err error
+
+	entry.Before, err = s.encode(entity, before)
+	if err != nil {
+		return err
+	}
+
+	entry.After, err = s.encode(entity, after)
+	if err != nil {
+		return err
+	}
+
+	err = s.r.AddEntry(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("error adding audit entry from repository %w", err)
+	}
+
+	err = change()
+	if err != nil {
+		failed := entry
+		failed.Id = uuid.New().String()
+		failed.Action = model.ActionFailed
+		failed.At = s.clock.Now().UTC()
+		failed.Before, failed.After = entry.After, entry.Before
+
+		addErr := s.r.AddEntry(ctx, failed)
+		if addErr != nil {
+			return fmt.Errorf("error adding failed audit entry of %s %v %w", entry.Id, addErr, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// GetEntries returns the entries matching the filter, oldest first.
+func (s *service) GetEntries(ctx context.Context, filter model.Filter) ([]model.Entry, error) {
+	entries, err := s.r.GetEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching audit entries from repository %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *service) encode(entity string, v any) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	represent, ok := s.representations[entity]
+	if !ok {
+		return "", fmt.Errorf("no audit representation of %s", entity)
+	}
+
+	represented, err := represent(v)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(represented)
+	if err != nil {
+		return "", fmt.Errorf("error encoding audit value %w", err)
+	}
+
+	return string(b), nil
+}
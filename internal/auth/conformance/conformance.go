@@ -0,0 +1,264 @@
+// Package conformance verifies that an implementation of auth.Repository behaves like every
+// other backend.
+package conformance
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/auth"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+
+	"github.com/google/uuid"
+)
+
+// NewRepositories returns an empty auth repository together with the players repository its
+// users are linked to.
+type NewRepositories func(t *testing.T) (players.Repository, auth.Repository)
+
+// Run runs the suite against the repositories returned by newRepositories.
+func Run(t *testing.T, newRepositories NewRepositories) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("empty repository has no users or tokens", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+
+		count, err := r.CountUsers(ctx)
+		if err != nil || count != 0 {
+			t.Errorf("expected %d users and no error got %d and %v", 0, count, err)
+		}
+
+		u, err := r.GetUserByUsername(ctx, "unknown")
+		if err != nil || u != nil {
+			t.Errorf("expected no user and no error got %v and %v", u, err)
+		}
+
+		token, err := r.GetApiTokenByHash(ctx, "unknown")
+		if err != nil || token != nil {
+			t.Errorf("expected no token and no error got %v and %v", token, err)
+		}
+	})
+	t.Run("created user is returned with every field", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		created := model.User{Id: "1", Username: "alice", PasswordHash: "hash", PlayerId: createPlayer(t, p, "Alice"), Role: model.RolePlayer, CreatedAt: now()}
+		createUser(t, r, created)
+
+		for _, find := range []func() (*model.User, error){
+			func() (*model.User, error) { return r.GetUserById(ctx, "1") },
+			func() (*model.User, error) { return r.GetUserByUsername(ctx, "alice") },
+		} {
+			got, err := find()
+			if err != nil || got == nil {
+				t.Fatalf("expected user and no error got %v and %v", got, err)
+			}
+
+			if got.Id != created.Id || got.Username != created.Username || got.PasswordHash != created.PasswordHash ||
+				got.PlayerId != created.PlayerId || got.Role != created.Role || !got.CreatedAt.Equal(created.CreatedAt) {
+				t.Errorf("expected %v got %v", created, got)
+			}
+		}
+
+		count, _ := r.CountUsers(ctx)
+		if count != 1 {
+			t.Errorf("expected %d got %d", 1, count)
+		}
+	})
+	t.Run("duplicate username is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+		createUser(t, r, model.User{Id: "1", Username: "alice", PasswordHash: "hash", CreatedAt: now()})
+
+		err := r.CreateUser(ctx, model.User{Id: "2", Username: "alice", PasswordHash: "hash", CreatedAt: now()})
+		if ierrors.KindOf(err) != ierrors.KindConflict {
+			t.Errorf("expected conflict error got %v", err)
+		}
+	})
+	t.Run("player is linked to one user only", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		createUser(t, r, model.User{Id: "1", Username: "alice", PasswordHash: "hash", PlayerId: playerId, CreatedAt: now()})
+		createUser(t, r, model.User{Id: "2", Username: "bob", PasswordHash: "hash", CreatedAt: now()})
+
+		err := r.CreateUser(ctx, model.User{Id: "3", Username: "carol", PasswordHash: "hash", PlayerId: playerId, CreatedAt: now()})
+		if ierrors.KindOf(err) != ierrors.KindConflict {
+			t.Errorf("expected conflict error got %v", err)
+		}
+	})
+	t.Run("deleting the player unlinks the user", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		createUser(t, r, model.User{Id: "1", Username: "alice", PasswordHash: "hash", PlayerId: playerId, CreatedAt: now()})
+
+		_, err := p.DeletePlayer(ctx, playerId, now())
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, err := r.GetUserById(ctx, "1")
+		if err != nil || got == nil || got.PlayerId != "" {
+			t.Errorf("expected user without player and no error got %v and %v", got, err)
+		}
+	})
+	t.Run("restoring the player links the user again", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		createUser(t, r, model.User{Id: "1", Username: "alice", PasswordHash: "hash", PlayerId: playerId, CreatedAt: now()})
+
+		_, _ = p.DeletePlayer(ctx, playerId, now())
+
+		_, err := p.RestorePlayer(ctx, playerId)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, err := r.GetUserByUsername(ctx, "alice")
+		if err != nil || got == nil || got.PlayerId != playerId {
+			t.Errorf("expected user of player %s and no error got %v and %v", playerId, got, err)
+		}
+	})
+	t.Run("first user is only created once", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+
+		results := make(chan bool, 5)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < cap(results); i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				id := strconv.Itoa(i)
+
+				created, err := r.CreateFirstUser(ctx, model.User{Id: id, Username: "user" + id, PasswordHash: "hash", Role: model.RoleAdmin, CreatedAt: now()})
+				if err != nil {
+					t.Errorf("got error: %v expected none", err)
+				}
+
+				results <- created
+			}(i)
+		}
+
+		wg.Wait()
+		close(results)
+
+		created := 0
+
+		for ok := range results {
+			if ok {
+				created++
+			}
+		}
+
+		count, _ := r.CountUsers(ctx)
+		if created != 1 || count != 1 {
+			t.Errorf("expected %d created and %d users got %d and %d", 1, 1, created, count)
+		}
+
+		ok, err := r.CreateFirstUser(ctx, model.User{Id: "later", Username: "later", PasswordHash: "hash", CreatedAt: now()})
+		if err != nil || ok {
+			t.Errorf("expected no user created and no error got %v and %v", ok, err)
+		}
+	})
+	t.Run("first user is not created once there are users", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+		createUser(t, r, model.User{Id: "1", Username: "alice", PasswordHash: "hash", CreatedAt: now()})
+
+		ok, err := r.CreateFirstUser(ctx, model.User{Id: "2", Username: "bob", PasswordHash: "hash", CreatedAt: now()})
+		if err != nil || ok {
+			t.Errorf("expected no user created and no error got %v and %v", ok, err)
+		}
+	})
+	t.Run("api tokens belong to their user", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+		createUser(t, r, model.User{Id: "1", Username: "alice", PasswordHash: "hash", CreatedAt: now()})
+		createUser(t, r, model.User{Id: "2", Username: "bob", PasswordHash: "hash", CreatedAt: now()})
+
+		tokens := []model.ApiToken{
+			{Id: "a", UserId: "1", Name: "second", Hash: "hash-a", CreatedAt: now().Add(time.Minute)},
+			{Id: "b", UserId: "1", Name: "first", Hash: "hash-b", CreatedAt: now()},
+			{Id: "c", UserId: "2", Name: "other", Hash: "hash-c", CreatedAt: now()},
+		}
+
+		for _, token := range tokens {
+			err := r.AddApiToken(ctx, token)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+		}
+
+		all, err := r.GetApiTokens(ctx, "1")
+		if err != nil || len(all) != 2 || all[0].Id != "b" || all[1].Id != "a" {
+			t.Fatalf("expected tokens b and a and no error got %v and %v", all, err)
+		}
+
+		got, err := r.GetApiTokenByHash(ctx, "hash-a")
+		if err != nil || got == nil || got.Id != "a" || got.UserId != "1" || got.Name != "second" || !got.CreatedAt.Equal(tokens[0].CreatedAt) {
+			t.Errorf("expected %v and no error got %v and %v", tokens[0], got, err)
+		}
+
+		err = r.DeleteApiToken(ctx, "2", "a")
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+
+		err = r.DeleteApiToken(ctx, "1", "a")
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, _ = r.GetApiTokenByHash(ctx, "hash-a")
+		if got != nil {
+			t.Errorf("expected no token got %v", got)
+		}
+	})
+}
+
+func createPlayer(t *testing.T, p players.Repository, name string) string {
+	t.Helper()
+
+	created, err := p.CreatePlayer(context.Background(), uuid.New().String(), name)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	return created.Id
+}
+
+func createUser(t *testing.T, r auth.Repository, user model.User) {
+	t.Helper()
+
+	err := r.CreateUser(context.Background(), user)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+}
+
+// now is truncated to what every backend stores.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/ierrors"
+)
+
+// GetUserByIdQuery a user stays linked to a deleted player, the link is hidden until the player is
+// restored and dropped once the player is purged.
+const GetUserByIdQuery = `
+	SELECT u.id, u.username, u.password_hash, COALESCE(p.id, ''), u.role, u.created_at
+	FROM app_user u LEFT JOIN player p ON (p.id = u.player_id AND NOT p.deleted)
+	WHERE u.id = $1;
+`
+
+const GetUserByUsernameQuery = `
+	SELECT u.id, u.username, u.password_hash, COALESCE(p.id, ''), u.role, u.created_at
+	FROM app_user u LEFT JOIN player p ON (p.id = u.player_id AND NOT p.deleted)
+	WHERE u.username = $1;
+`
+
+const CountUsersQuery = "SELECT count(*) FROM app_user;"
+const CountUsersByUsernameQuery = "SELECT count(*) FROM app_user WHERE username = $1;"
+const CountUsersByPlayerIdQuery = "SELECT count(*) FROM app_user WHERE player_id = $1;"
+
+const InsertUserQuery = `
+	INSERT INTO app_user (id, username, password_hash, player_id, role, created_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6);
+`
+
+// InsertFirstUserQuery inserts nothing once there are users, the unique first_user flag lets only
+// one of concurrent inserts through.
+const InsertFirstUserQuery = `
+	INSERT INTO app_user (id, username, password_hash, player_id, role, created_at, first_user)
+	SELECT $1, $2, $3, NULLIF($4, ''), $5, $6, TRUE
+	WHERE NOT EXISTS (SELECT 1 FROM app_user)
+	ON CONFLICT (first_user) DO NOTHING;
+`
+
+const GetApiTokenByHashQuery = "SELECT id, user_id, name, token_hash, created_at FROM api_token WHERE token_hash = $1;"
+const GetApiTokensQuery = "SELECT id, user_id, name, token_hash, created_at FROM api_token WHERE user_id = $1 ORDER BY created_at;"
+const InsertApiTokenQuery = "INSERT INTO api_token (id, user_id, name, token_hash, created_at) VALUES ($1, $2, $3, $4, $5);"
+const DeleteApiTokenQuery = "DELETE FROM api_token WHERE user_id = $1 AND id = $2;"
+
+// PostgresRepository the queries are kept portable so the repository also serves the SQLite mode.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) GetUserById(ctx context.Context, id string) (*model.User, error) {
+	return r.queryUser(ctx, GetUserByIdQuery, id)
+}
+
+func (r *PostgresRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	return r.queryUser(ctx, GetUserByUsernameQuery, username)
+}
+
+func (r *PostgresRepository) CountUsers(ctx context.Context) (int, error) {
+	var count int
+
+	err := r.db.QueryRowContext(ctx, CountUsersQuery).Scan(&count)
+	if err != nil {
+		return 0, ierrors.Database("error counting users", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresRepository) CreateUser(ctx context.Context, user model.User) error {
+	count, err := r.count(ctx, CountUsersByUsernameQuery, user.Username)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ierrors.Conflict(fmt.Sprintf("username %s already exists", user.Username))
+	}
+
+	if user.PlayerId != "" {
+		count, err = r.count(ctx, CountUsersByPlayerIdQuery, user.PlayerId)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return ierrors.Conflict(fmt.Sprintf("player with id %s is already linked to a user", user.PlayerId))
+		}
+	}
+
+	_, err = r.db.ExecContext(ctx, InsertUserQuery, user.Id, user.Username, user.PasswordHash, user.PlayerId, user.Role, user.CreatedAt)
+	if err != nil {
+		return ierrors.Database("error executing insert user query", err)
+	}
+
+	return nil
+}
+
+// CreateFirstUser creates the user unless there already is a user and reports whether it did.
+func (r *PostgresRepository) CreateFirstUser(ctx context.Context, user model.User) (bool, error) {
+	result, err := r.db.ExecContext(ctx, InsertFirstUserQuery, user.Id, user.Username, user.PasswordHash, user.PlayerId, user.Role, user.CreatedAt)
+	if err != nil {
+		return false, ierrors.Database("error executing insert first user query", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, ierrors.Database("error reading inserted rows", err)
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresRepository) GetApiTokenByHash(ctx context.Context, hash string) (*model.ApiToken, error) {
+	tokens, err := r.queryApiTokens(ctx, GetApiTokenByHashQuery, hash)
+	if err != nil || len(tokens) == 0 {
+		return nil, err
+	}
+
+	return &tokens[0], nil
+}
+
+// GetApiTokens returns the tokens of the user ordered by creation.
+func (r *PostgresRepository) GetApiTokens(ctx context.Context, userId string) ([]model.ApiToken, error) {
+	return r.queryApiTokens(ctx, GetApiTokensQuery, userId)
+}
+
+func (r *PostgresRepository) AddApiToken(ctx context.Context, token model.ApiToken) error {
+	_, err := r.db.ExecContext(ctx, InsertApiTokenQuery, token.Id, token.UserId, token.Name, token.Hash, token.CreatedAt)
+	if err != nil {
+		return ierrors.Database("error executing insert api token query", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) DeleteApiToken(ctx context.Context, userId, id string) error {
+	result, err := r.db.ExecContext(ctx, DeleteApiTokenQuery, userId, id)
+	if err != nil {
+		return ierrors.Database("error executing delete api token query", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return ierrors.Database("error reading deleted api tokens", err)
+	}
+
+	if deleted == 0 {
+		return ierrors.NotFound(fmt.Sprintf("api token with id %s does not exist", id))
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) queryUser(ctx context.Context, query string, param string) (*model.User, error) {
+	var u model.User
+
+	err := r.db.QueryRowContext(ctx, query, param).Scan(&u.Id, &u.Username, &u.PasswordHash, &u.PlayerId, &u.Role, &u.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ierrors.Database("error scanning user", err)
+	}
+
+	return &u, nil
+}
+
+func (r *PostgresRepository) queryApiTokens(ctx context.Context, query string, param string) ([]model.ApiToken, error) {
+	rows, err := r.db.QueryContext(ctx, query, param)
+	if err != nil {
+		return nil, ierrors.Database("error fetching api tokens", err)
+	}
+
+	defer rows.Close()
+
+	tokens := make([]model.ApiToken, 0)
+
+	for rows.Next() {
+		var t model.ApiToken
+
+		err = rows.Scan(&t.Id, &t.UserId, &t.Name, &t.Hash, &t.CreatedAt)
+		if err != nil {
+			return nil, ierrors.Database("error scanning api tokens", err)
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading api tokens", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *PostgresRepository) count(ctx context.Context, query string, param string) (int, error) {
+	var count int
+
+	err := r.db.QueryRowContext(ctx, query, param).Scan(&count)
+	if err != nil {
+		return 0, ierrors.Database("error running user count query", err)
+	}
+
+	return count, nil
+}
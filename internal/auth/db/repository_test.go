@@ -0,0 +1,35 @@
+package db_test
+
+import (
+	"testing"
+	"tour-le-shit-go/internal/auth"
+	"tour-le-shit-go/internal/auth/conformance"
+	"tour-le-shit-go/internal/auth/db"
+	"tour-le-shit-go/internal/dbtest"
+	"tour-le-shit-go/internal/players"
+	playersDb "tour-le-shit-go/internal/players/db"
+)
+
+func TestSqliteConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) (players.Repository, auth.Repository) {
+		t.Helper()
+
+		database := dbtest.Sqlite(t)
+
+		return playersDb.NewRepository(database), db.NewRepository(database)
+	})
+}
+
+func TestPostgresConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) (players.Repository, auth.Repository) {
+		t.Helper()
+
+		database := dbtest.Postgres(t)
+
+		return playersDb.NewRepository(database), db.NewRepository(database)
+	})
+}
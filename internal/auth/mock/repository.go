@@ -0,0 +1,223 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/utils"
+)
+
+// MockedRepository keeps the users and API tokens in memory and is safe for concurrent use. Like
+// the database a user is unlinked from its player once the player is deleted.
+type MockedRepository struct {
+	mu      sync.RWMutex
+	users   []model.User
+	tokens  []model.ApiToken
+	players players.Repository
+}
+
+// snapshot is the content of the file written by Save.
+type snapshot struct {
+	Users  []model.User
+	Tokens []model.ApiToken
+}
+
+func NewRepository(users []model.User, p players.Repository) *MockedRepository {
+	return &MockedRepository{users: users, tokens: make([]model.ApiToken, 0), players: p}
+}
+
+func (r *MockedRepository) GetUserById(ctx context.Context, id string) (*model.User, error) {
+	return r.findUser(ctx, func(u model.User) bool {
+		return u.Id == id
+	})
+}
+
+func (r *MockedRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	return r.findUser(ctx, func(u model.User) bool {
+		return u.Username == username
+	})
+}
+
+func (r *MockedRepository) CountUsers(_ context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.users), nil
+}
+
+func (r *MockedRepository) CreateUser(ctx context.Context, user model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range r.users {
+		if u.Username == user.Username {
+			return ierrors.Conflict(fmt.Sprintf("username %s already exists", user.Username))
+		}
+
+		if user.PlayerId == "" || u.PlayerId != user.PlayerId {
+			continue
+		}
+
+		linked, err := r.linked(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		if linked.PlayerId != "" {
+			return ierrors.Conflict(fmt.Sprintf("player with id %s is already linked to a user", user.PlayerId))
+		}
+	}
+
+	r.users = append(r.users, user)
+
+	return nil
+}
+
+// CreateFirstUser creates the user unless there already is a user and reports whether it did.
+func (r *MockedRepository) CreateFirstUser(_ context.Context, user model.User) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.users) > 0 {
+		return false, nil
+	}
+
+	r.users = append(r.users, user)
+
+	return true, nil
+}
+
+func (r *MockedRepository) GetApiTokenByHash(_ context.Context, hash string) (*model.ApiToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tokens {
+		if t.Hash == hash {
+			return &t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetApiTokens returns the tokens of the user ordered by creation.
+func (r *MockedRepository) GetApiTokens(_ context.Context, userId string) ([]model.ApiToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.ApiToken, 0)
+
+	for _, t := range r.tokens {
+		if t.UserId == userId {
+			result = append(result, t)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *MockedRepository) AddApiToken(_ context.Context, token model.ApiToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens = append(r.tokens, token)
+
+	return nil
+}
+
+func (r *MockedRepository) DeleteApiToken(_ context.Context, userId, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updatedTokens := make([]model.ApiToken, 0)
+
+	for _, t := range r.tokens {
+		if t.Id != id || t.UserId != userId {
+			updatedTokens = append(updatedTokens, t)
+		}
+	}
+
+	if len(updatedTokens) == len(r.tokens) {
+		return ierrors.NotFound(fmt.Sprintf("api token with id %s does not exist", id))
+	}
+
+	r.tokens = updatedTokens
+
+	return nil
+}
+
+// Load replaces the users and API tokens with the ones saved to path, nothing changes if the
+// file is missing.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var s snapshot
+	if err := utils.ReadJson(path, &s); err != nil {
+		return err
+	}
+
+	if s.Users != nil {
+		r.users = s.Users
+	}
+
+	if s.Tokens != nil {
+		r.tokens = s.Tokens
+	}
+
+	return nil
+}
+
+// Save writes the users and API tokens to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, snapshot{Users: r.users, Tokens: r.tokens})
+}
+
+func (r *MockedRepository) findUser(ctx context.Context, match func(u model.User) bool) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, u := range r.users {
+		if !match(u) {
+			continue
+		}
+
+		linked, err := r.linked(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+
+		return &linked, nil
+	}
+
+	return nil, nil
+}
+
+// linked returns the user without its player while the player is deleted.
+func (r *MockedRepository) linked(ctx context.Context, u model.User) (model.User, error) {
+	if u.PlayerId == "" {
+		return u, nil
+	}
+
+	p, err := r.players.GetPlayerById(ctx, u.PlayerId)
+	if err != nil {
+		return u, err
+	}
+
+	if p == nil {
+		u.PlayerId = ""
+	}
+
+	return u, nil
+}
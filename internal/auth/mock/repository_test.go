@@ -0,0 +1,64 @@
+package mock_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/auth"
+	"tour-le-shit-go/internal/auth/conformance"
+	"tour-le-shit-go/internal/auth/mock"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/players"
+	playersMock "tour-le-shit-go/internal/players/mock"
+	playersModel "tour-le-shit-go/internal/players/model"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) (players.Repository, auth.Repository) {
+		t.Helper()
+
+		p := playersMock.NewRepository([]playersModel.Player{})
+
+		return p, mock.NewRepository([]model.User{}, p)
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path := filepath.Join(t.TempDir(), "auth.json")
+	p := playersMock.NewRepository([]playersModel.Player{})
+
+	saved := mock.NewRepository([]model.User{}, p)
+	_ = saved.CreateUser(context.Background(), model.User{Id: "1", Username: "alice", PasswordHash: "hash", CreatedAt: time.Now()})
+	_ = saved.AddApiToken(context.Background(), model.ApiToken{Id: "a", UserId: "1", Name: "script", Hash: "hash-a", CreatedAt: time.Now()})
+
+	err := saved.Save(path)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	loaded := mock.NewRepository([]model.User{}, p)
+
+	// act
+	err = loaded.Load(path)
+
+	// assert
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	user, _ := loaded.GetUserByUsername(context.Background(), "alice")
+	if user == nil || user.Id != "1" {
+		t.Errorf("expected alice got %v", user)
+	}
+
+	token, _ := loaded.GetApiTokenByHash(context.Background(), "hash-a")
+	if token == nil || token.UserId != "1" {
+		t.Errorf("expected token of alice got %v", token)
+	}
+}
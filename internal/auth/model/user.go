@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// Roles of a user, see the policy package for what each role may do.
+const (
+	RoleAdmin       = "admin"
+	RoleScorekeeper = "scorekeeper"
+	RolePlayer      = "player"
+	RoleViewer      = "viewer"
+)
+
+// User an account which can log in, linked to the player it plays as when PlayerId is set.
+type User struct {
+	Id           string
+	Username     string
+	PasswordHash string
+	PlayerId     string
+	Role         string
+	CreatedAt    time.Time
+}
+
+type UserInput struct {
+	Username string
+	Password string
+	PlayerId string
+	Role     string
+}
+
+// ApiToken a long-lived token for scripts, only the hash of the token is stored.
+type ApiToken struct {
+	Id        string
+	UserId    string
+	Name      string
+	Hash      string
+	CreatedAt time.Time
+}
+
+// Session a signed token issued at login which is valid until ExpiresAt.
+type Session struct {
+	Token     string
+	User      User
+	ExpiresAt time.Time
+}
@@ -0,0 +1,340 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/policy"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ApiTokenPrefix tells API tokens apart from session tokens.
+const ApiTokenPrefix = "tls_"
+const SessionDuration = 7 * 24 * time.Hour
+const MinPasswordLength = 8
+
+// MaxPasswordLength bcrypt ignores anything longer.
+const MaxPasswordLength = 72
+
+// dummyPasswordHash is compared against when the username is unknown, so a login takes as long
+// whether the user exists or not.
+const dummyPasswordHash = "$2a$10$2ARTjXePTlplN.0zcYv1yOJwo4aN7uHxo3pRARQByaV6tsrVPXKUC"
+
+type Repository interface {
+	GetUserById(ctx context.Context, id string) (*model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+	CountUsers(ctx context.Context) (int, error)
+	CreateUser(ctx context.Context, user model.User) error
+	CreateFirstUser(ctx context.Context, user model.User) (bool, error)
+	GetApiTokenByHash(ctx context.Context, hash string) (*model.ApiToken, error)
+	GetApiTokens(ctx context.Context, userId string) ([]model.ApiToken, error)
+	AddApiToken(ctx context.Context, token model.ApiToken) error
+	DeleteApiToken(ctx context.Context, userId, id string) error
+}
+
+type Service interface {
+	Register(ctx context.Context, input model.UserInput) (*model.User, error)
+	Login(ctx context.Context, username, password string) (*model.Session, error)
+	Authenticate(ctx context.Context, token string) (*model.User, error)
+	CreateApiToken(ctx context.Context, user model.User, name string) (string, *model.ApiToken, error)
+	GetApiTokens(ctx context.Context, user model.User) ([]model.ApiToken, error)
+	RevokeApiToken(ctx context.Context, user model.User, id string) error
+}
+
+type service struct {
+	r       Repository
+	players players.Service
+	secret  []byte
+	clock   utils.Clock
+}
+
+// NewService returns a service signing its session tokens with secret.
+func NewService(r Repository, p players.Service, secret []byte, clock utils.Clock) Service {
+	return &service{r: r, players: p, secret: secret, clock: clock}
+}
+
+// Register creates a user. Anyone may create the first user, who becomes an admin, after that
+// only admins can create users. Users are viewers unless another role is given.
+func (s *service) Register(ctx context.Context, input model.UserInput) (*model.User, error) {
+	count, err := s.r.CountUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error counting users from repository %w", err)
+	}
+
+	first := count == 0
+
+	switch {
+	case first:
+		input.Role = model.RoleAdmin
+	case policy.UserFrom(ctx) == nil:
+		return nil, ierrors.Unauthorized("log in to create users")
+	default:
+		err = policy.Admin(ctx, "create users")
+		if err != nil {
+			return nil, fmt.Errorf("error authorising %w", err)
+		}
+	}
+
+	if input.Role == "" {
+		input.Role = model.RoleViewer
+	}
+
+	err = s.validate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password %w", err)
+	}
+
+	user := model.User{
+		Id:           uuid.New().String(),
+		Username:     input.Username,
+		PasswordHash: string(hash),
+		PlayerId:     input.PlayerId,
+		Role:         input.Role,
+		CreatedAt:    s.clock.Now().UTC(),
+	}
+
+	if first {
+		return s.createFirstUser(ctx, user)
+	}
+
+	err = s.r.CreateUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user from repository %w", err)
+	}
+
+	return &user, nil
+}
+
+// createFirstUser creates the admin unless another request created the first user in the
+// meantime, the request is then treated like any other anonymous request.
+func (s *service) createFirstUser(ctx context.Context, user model.User) (*model.User, error) {
+	created, err := s.r.CreateFirstUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("error creating first user from repository %w", err)
+	}
+
+	if !created {
+		return nil, ierrors.Unauthorized("log in to create users")
+	}
+
+	return &user, nil
+}
+
+// Login issues a session token when the password of the user matches.
+func (s *service) Login(ctx context.Context, username, password string) (*model.Session, error) {
+	user, err := s.r.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user %s from repository %w", username, err)
+	}
+
+	hash := dummyPasswordHash
+	if user != nil {
+		hash = user.PasswordHash
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if user == nil || err != nil {
+		return nil, ierrors.Unauthorized("invalid username or password")
+	}
+
+	expiresAt := s.clock.Now().Add(SessionDuration).Truncate(time.Second)
+
+	return &model.Session{
+		Token:     s.sign(user.Id, expiresAt),
+		User:      *user,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Authenticate returns the user of a session token or an API token.
+func (s *service) Authenticate(ctx context.Context, token string) (*model.User, error) {
+	var userId string
+
+	if strings.HasPrefix(token, ApiTokenPrefix) {
+		t, err := s.r.GetApiTokenByHash(ctx, hashToken(token))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching api token from repository %w", err)
+		}
+
+		if t == nil {
+			return nil, ierrors.Unauthorized("invalid api token")
+		}
+
+		userId = t.UserId
+	} else {
+		id, err := s.verify(token)
+		if err != nil {
+			return nil, err
+		}
+
+		userId = id
+	}
+
+	user, err := s.r.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user with id %s from repository %w", userId, err)
+	}
+
+	if user == nil {
+		return nil, ierrors.Unauthorized("the user of the token does not exist")
+	}
+
+	return user, nil
+}
+
+// CreateApiToken returns the token together with what is stored about it, the token itself can
+// not be recovered later.
+func (s *service) CreateApiToken(ctx context.Context, user model.User, name string) (string, *model.ApiToken, error) {
+	if name == "" {
+		return "", nil, ierrors.Invalid("name", "name can not be empty")
+	}
+
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating api token %w", err)
+	}
+
+	token := ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	t := model.ApiToken{
+		Id:        uuid.New().String(),
+		UserId:    user.Id,
+		Name:      name,
+		Hash:      hashToken(token),
+		CreatedAt: s.clock.Now().UTC(),
+	}
+
+	err = s.r.AddApiToken(ctx, t)
+	if err != nil {
+		return "", nil, fmt.Errorf("error adding api token from repository %w", err)
+	}
+
+	return token, &t, nil
+}
+
+func (s *service) GetApiTokens(ctx context.Context, user model.User) ([]model.ApiToken, error) {
+	tokens, err := s.r.GetApiTokens(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching api tokens from repository %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *service) RevokeApiToken(ctx context.Context, user model.User, id string) error {
+	err := s.r.DeleteApiToken(ctx, user.Id, id)
+	if err != nil {
+		return fmt.Errorf("error deleting api token from repository %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) validate(ctx context.Context, input model.UserInput) error {
+	if input.Username == "" {
+		return ierrors.Invalid("username", "username can not be empty")
+	}
+
+	if len(input.Password) < MinPasswordLength || len(input.Password) > MaxPasswordLength {
+		return ierrors.Invalid("password", fmt.Sprintf("password must be %d to %d characters", MinPasswordLength, MaxPasswordLength))
+	}
+
+	if !policy.IsRole(input.Role) {
+		return ierrors.Invalid("role", fmt.Sprintf("invalid role %s, expected admin, scorekeeper, player or viewer", input.Role))
+	}
+
+	if input.PlayerId == "" && input.Role == model.RolePlayer {
+		return ierrors.Invalid("playerId", "users with the player role must be linked to a player")
+	}
+
+	if input.PlayerId == "" {
+		return nil
+	}
+
+	p, err := s.players.GetMember(ctx, input.PlayerId)
+	if err != nil {
+		return fmt.Errorf("error fetching player %w", err)
+	}
+
+	if p == nil {
+		return ierrors.Invalid("playerId", fmt.Sprintf("player with id %s does not exist", input.PlayerId))
+	}
+
+	return nil
+}
+
+// sign returns a session token of the user, the expiry and user id are signed with the secret
+// of the service so sessions need no storage.
+func (s *service) sign(userId string, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%s|%d", userId, expiresAt.Unix())
+
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// verify returns the user id of a session token signed by the service that has not expired.
+func (s *service) verify(token string) (string, error) {
+	invalid := ierrors.Unauthorized("invalid session token")
+
+	encodedPayload, encodedMac, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", invalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", invalid
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMac)
+	if err != nil || !hmac.Equal(mac, s.mac(string(payload))) {
+		return "", invalid
+	}
+
+	userId, expiry, ok := strings.Cut(string(payload), "|")
+	if !ok {
+		return "", invalid
+	}
+
+	seconds, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", invalid
+	}
+
+	if !s.clock.Now().Before(time.Unix(seconds, 0)) {
+		return "", ierrors.Unauthorized("the session has expired, please log in again")
+	}
+
+	return userId, nil
+}
+
+func (s *service) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+
+	return h.Sum(nil)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
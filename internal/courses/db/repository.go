@@ -0,0 +1,298 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/ierrors"
+
+	"github.com/google/uuid"
+)
+
+const GetCourseByIdQuery = "SELECT id, name FROM course WHERE id = $1"
+const GetCoursesQuery = "SELECT id, name FROM course ORDER BY name;"
+const GetCountCoursesByIdQuery = "SELECT count(*) FROM course WHERE id = $1"
+const GetCountCoursesByNameQuery = "SELECT count(*) FROM course WHERE name = $1"
+const GetCourseHolesQuery = "SELECT hole, par, stroke_index FROM course_hole WHERE course_id = $1 ORDER BY hole;"
+const GetCourseTeesQuery = "SELECT name, course_rating, slope FROM course_tee WHERE course_id = $1 ORDER BY name;"
+const InsertCourseQuery = "INSERT INTO course (id, name) VALUES ($1, $2);"
+const InsertCourseHoleQuery = "INSERT INTO course_hole (course_id, hole, par, stroke_index) VALUES ($1, $2, $3, $4);"
+const InsertCourseTeeQuery = "INSERT INTO course_tee (course_id, name, course_rating, slope) VALUES ($1, $2, $3, $4);"
+const UpdateCourseQuery = "UPDATE course SET name = $2 WHERE id = $1;"
+const DeleteCourseHolesQuery = "DELETE FROM course_hole WHERE course_id = $1;"
+const DeleteCourseTeesQuery = "DELETE FROM course_tee WHERE course_id = $1;"
+const DeleteCourseQuery = "DELETE FROM course WHERE id = $1;"
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) GetCourseById(ctx context.Context, id string) (*model.Course, error) {
+	var course model.Course
+
+	err := r.db.QueryRowContext(ctx, GetCourseByIdQuery, id).Scan(&course.Id, &course.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ierrors.Database("error scanning course", err)
+	}
+
+	err = r.addHolesAndTees(ctx, &course)
+	if err != nil {
+		return nil, err
+	}
+
+	return &course, nil
+}
+
+func (r *PostgresRepository) GetCourses(ctx context.Context) ([]model.Course, error) {
+	rows, err := r.db.QueryContext(ctx, GetCoursesQuery)
+	if err != nil {
+		return nil, ierrors.Database("error fetching courses", err)
+	}
+
+	defer rows.Close()
+
+	courses := make([]model.Course, 0)
+
+	for rows.Next() {
+		var course model.Course
+
+		err = rows.Scan(&course.Id, &course.Name)
+		if err != nil {
+			return nil, ierrors.Database("error scanning rows", err)
+		}
+
+		courses = append(courses, course)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading courses", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return nil, ierrors.Database("error closing courses", err)
+	}
+
+	for i := range courses {
+		err = r.addHolesAndTees(ctx, &courses[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return courses, nil
+}
+
+func (r *PostgresRepository) CreateCourse(ctx context.Context, input model.CourseInput) (*model.Course, error) {
+	count, err := r.countCoursesByQuery(ctx, GetCountCoursesByNameQuery, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 0 {
+		return nil, ierrors.Conflict(fmt.Sprintf("course with name %s already exists.", input.Name))
+	}
+
+	id := uuid.New().String()
+
+	err = r.inTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, InsertCourseQuery, id, input.Name)
+		if err != nil {
+			return ierrors.Database("error executing insert course query", err)
+		}
+
+		return insertHolesAndTees(ctx, tx, id, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetCourseById(ctx, id)
+}
+
+func (r *PostgresRepository) UpdateCourse(ctx context.Context, id string, input model.CourseInput) (*model.Course, error) {
+	count, err := r.countCoursesByQuery(ctx, GetCountCoursesByIdQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("course with id %s does not exist", id))
+	}
+
+	err = r.inTransaction(ctx, func(tx *sql.Tx) error {
+		for _, query := range []string{DeleteCourseHolesQuery, DeleteCourseTeesQuery} {
+			_, err := tx.ExecContext(ctx, query, id)
+			if err != nil {
+				return ierrors.Database("error clearing course holes and tees", err)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, UpdateCourseQuery, id, input.Name)
+		if err != nil {
+			return ierrors.Database("error executing update course query", err)
+		}
+
+		return insertHolesAndTees(ctx, tx, id, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetCourseById(ctx, id)
+}
+
+func (r *PostgresRepository) DeleteCourse(ctx context.Context, id string) error {
+	count, err := r.countCoursesByQuery(ctx, GetCountCoursesByIdQuery, id)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ierrors.NotFound(fmt.Sprintf("course with id %s does not exist", id))
+	}
+
+	return r.inTransaction(ctx, func(tx *sql.Tx) error {
+		for _, query := range []string{DeleteCourseHolesQuery, DeleteCourseTeesQuery, DeleteCourseQuery} {
+			_, err := tx.ExecContext(ctx, query, id)
+			if err != nil {
+				return ierrors.Database("error executing delete course query", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PostgresRepository) addHolesAndTees(ctx context.Context, course *model.Course) error {
+	holes, err := r.queryHoles(ctx, course.Id)
+	if err != nil {
+		return err
+	}
+
+	tees, err := r.queryTees(ctx, course.Id)
+	if err != nil {
+		return err
+	}
+
+	course.Holes = holes
+	course.Tees = tees
+
+	return nil
+}
+
+func (r *PostgresRepository) queryHoles(ctx context.Context, courseId string) ([]model.Hole, error) {
+	rows, err := r.db.QueryContext(ctx, GetCourseHolesQuery, courseId)
+	if err != nil {
+		return nil, ierrors.Database("error fetching course holes", err)
+	}
+
+	defer rows.Close()
+
+	holes := make([]model.Hole, 0)
+
+	for rows.Next() {
+		var h model.Hole
+
+		err = rows.Scan(&h.Number, &h.Par, &h.StrokeIndex)
+		if err != nil {
+			return nil, ierrors.Database("error scanning course holes", err)
+		}
+
+		holes = append(holes, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading course holes", err)
+	}
+
+	return holes, nil
+}
+
+func (r *PostgresRepository) queryTees(ctx context.Context, courseId string) ([]model.Tee, error) {
+	rows, err := r.db.QueryContext(ctx, GetCourseTeesQuery, courseId)
+	if err != nil {
+		return nil, ierrors.Database("error fetching course tees", err)
+	}
+
+	defer rows.Close()
+
+	tees := make([]model.Tee, 0)
+
+	for rows.Next() {
+		var t model.Tee
+
+		err = rows.Scan(&t.Name, &t.CourseRating, &t.Slope)
+		if err != nil {
+			return nil, ierrors.Database("error scanning course tees", err)
+		}
+
+		tees = append(tees, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading course tees", err)
+	}
+
+	return tees, nil
+}
+
+func (r *PostgresRepository) inTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ierrors.Database("error starting transaction", err)
+	}
+
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return ierrors.Database("error committing transaction", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) countCoursesByQuery(ctx context.Context, query string, param string) (int, error) {
+	var count int
+
+	err := r.db.QueryRowContext(ctx, query, param).Scan(&count)
+	if err != nil {
+		return 0, ierrors.Database("error running course count query", err)
+	}
+
+	return count, nil
+}
+
+func insertHolesAndTees(ctx context.Context, tx *sql.Tx, id string, input model.CourseInput) error {
+	for _, h := range input.Holes {
+		_, err := tx.ExecContext(ctx, InsertCourseHoleQuery, id, h.Number, h.Par, h.StrokeIndex)
+		if err != nil {
+			return ierrors.Database("error executing insert course hole query", err)
+		}
+	}
+
+	for _, t := range input.Tees {
+		_, err := tx.ExecContext(ctx, InsertCourseTeeQuery, id, t.Name, t.CourseRating, t.Slope)
+		if err != nil {
+			return ierrors.Database("error executing insert course tee query", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"tour-le-shit-go/internal/courses/db"
+	"tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/dbtest"
+)
+
+func TestSqliteRepository(t *testing.T) {
+	t.Parallel()
+
+	run(t, dbtest.Sqlite)
+}
+
+func TestPostgresRepository(t *testing.T) {
+	t.Parallel()
+
+	run(t, dbtest.Postgres)
+}
+
+func run(t *testing.T, open func(t *testing.T) *sql.DB) {
+	t.Helper()
+
+	input := model.CourseInput{
+		Name:  "Links",
+		Holes: []model.Hole{{Number: 1, Par: 4, StrokeIndex: 2}, {Number: 2, Par: 3, StrokeIndex: 1}},
+		Tees:  []model.Tee{{Name: "white", CourseRating: 71, Slope: 125}, {Name: "yellow", CourseRating: 69.4, Slope: 120}},
+	}
+
+	t.Run("created course is returned with its holes and tees", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := db.NewRepository(open(t))
+
+		created, err := r.CreateCourse(context.Background(), input)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		// act
+		result, err := r.GetCourseById(context.Background(), created.Id)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := model.Course{Id: created.Id, Name: input.Name, Holes: input.Holes, Tees: input.Tees}
+		if result == nil || !reflect.DeepEqual(*result, expected) {
+			t.Errorf("expected %v got %v", expected, result)
+		}
+	})
+	t.Run("deleted course is gone", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := db.NewRepository(open(t))
+
+		created, err := r.CreateCourse(context.Background(), input)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		// act
+		err = r.DeleteCourse(context.Background(), created.Id)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		all, err := r.GetCourses(context.Background())
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 0 {
+			t.Errorf("expected %d got %d", 0, len(all))
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// MockedRepository keeps the courses in memory and is safe for concurrent use.
+type MockedRepository struct {
+	mu      sync.RWMutex
+	courses []model.Course
+}
+
+func NewRepository(courses []model.Course) *MockedRepository {
+	return &MockedRepository{courses: courses}
+}
+
+func (r *MockedRepository) GetCourseById(_ context.Context, id string) (*model.Course, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.courses {
+		if c.Id == id {
+			return &model.Course{
+				Id:    c.Id,
+				Name:  c.Name,
+				Holes: c.Holes,
+				Tees:  c.Tees,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockedRepository) GetCourses(_ context.Context) ([]model.Course, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.Course, len(r.courses))
+	copy(result, r.courses)
+
+	return result, nil
+}
+
+func (r *MockedRepository) CreateCourse(_ context.Context, input model.CourseInput) (*model.Course, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.courses {
+		if input.Name == c.Name {
+			return nil, ierrors.Conflict(fmt.Sprintf("course with name %s already exists.", input.Name))
+		}
+	}
+
+	course := model.Course{
+		Id:    uuid.New().String(),
+		Name:  input.Name,
+		Holes: input.Holes,
+		Tees:  input.Tees,
+	}
+
+	r.courses = append(r.courses, course)
+
+	return &course, nil
+}
+
+func (r *MockedRepository) UpdateCourse(_ context.Context, id string, input model.CourseInput) (*model.Course, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, c := range r.courses {
+		if c.Id == id {
+			course := model.Course{
+				Id:    id,
+				Name:  input.Name,
+				Holes: input.Holes,
+				Tees:  input.Tees,
+			}
+
+			r.courses[i] = course
+
+			return &course, nil
+		}
+	}
+
+	return nil, ierrors.NotFound(fmt.Sprintf("course with id %s does not exist", id))
+}
+
+func (r *MockedRepository) DeleteCourse(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updatedCourses := make([]model.Course, 0)
+
+	for _, c := range r.courses {
+		if c.Id != id {
+			updatedCourses = append(updatedCourses, c)
+		}
+	}
+
+	if len(updatedCourses) == len(r.courses) {
+		return ierrors.NotFound(fmt.Sprintf("course with id %s does not exist", id))
+	}
+
+	r.courses = updatedCourses
+
+	return nil
+}
+
+// Load replaces the courses with the ones saved to path, nothing changes if the file is missing.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var courses []model.Course
+	if err := utils.ReadJson(path, &courses); err != nil {
+		return err
+	}
+
+	if courses != nil {
+		r.courses = courses
+	}
+
+	return nil
+}
+
+// Save writes the courses to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, r.courses)
+}
@@ -0,0 +1,68 @@
+package model
+
+type Course struct {
+	Id    string
+	Name  string
+	Holes []Hole
+	Tees  []Tee
+}
+
+type CourseInput struct {
+	Name  string
+	Holes []Hole
+	Tees  []Tee
+}
+
+// Hole the par and stroke index of a hole on the course.
+type Hole struct {
+	Number      int
+	Par         int
+	StrokeIndex int
+}
+
+// Tee a set of tees on the course with its course rating and slope.
+type Tee struct {
+	Name         string
+	CourseRating float64
+	Slope        int
+}
+
+// Par returns the total par of the course.
+func (c Course) Par() int {
+	par := 0
+	for _, h := range c.Holes {
+		par += h.Par
+	}
+
+	return par
+}
+
+// Tee returns the tee with the given name or nil if it does not exist.
+func (c Course) Tee(name string) *Tee {
+	for _, t := range c.Tees {
+		if t.Name == name {
+			return &Tee{
+				Name:         t.Name,
+				CourseRating: t.CourseRating,
+				Slope:        t.Slope,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Hole returns the hole with the given number or nil if it does not exist.
+func (c Course) Hole(number int) *Hole {
+	for _, h := range c.Holes {
+		if h.Number == number {
+			return &Hole{
+				Number:      h.Number,
+				Par:         h.Par,
+				StrokeIndex: h.StrokeIndex,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package courses
+
+import (
+	"context"
+	"fmt"
+	"tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/policy"
+)
+
+const MaxHoles = 18
+const MinPar = 3
+const MaxPar = 6
+const MinSlope = 55
+const MaxSlope = 155
+
+type Repository interface {
+	GetCourseById(ctx context.Context, id string) (*model.Course, error)
+	GetCourses(ctx context.Context) ([]model.Course, error)
+	CreateCourse(ctx context.Context, input model.CourseInput) (*model.Course, error)
+	UpdateCourse(ctx context.Context, id string, input model.CourseInput) (*model.Course, error)
+	DeleteCourse(ctx context.Context, id string) error
+}
+
+type Service interface {
+	GetCourse(ctx context.Context, id string) (*model.Course, error)
+	GetCourses(ctx context.Context) ([]model.Course, error)
+	CreateCourse(ctx context.Context, input model.CourseInput) (*model.Course, error)
+	UpdateCourse(ctx context.Context, id string, input model.CourseInput) (*model.Course, error)
+	DeleteCourse(ctx context.Context, id string) error
+}
+
+type service struct {
+	r Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{r: r}
+}
+
+func (s *service) GetCourse(ctx context.Context, id string) (*model.Course, error) {
+	c, err := s.r.GetCourseById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching course with id %s from repository %w", id, err)
+	}
+
+	return c, nil
+}
+
+func (s *service) GetCourses(ctx context.Context) ([]model.Course, error) {
+	c, err := s.r.GetCourses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching courses from repository %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *service) CreateCourse(ctx context.Context, input model.CourseInput) (*model.Course, error) {
+	err := policy.Admin(ctx, "manage courses")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	err = validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.r.CreateCourse(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("error creating course from repository %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *service) UpdateCourse(ctx context.Context, id string, input model.CourseInput) (*model.Course, error) {
+	err := policy.Admin(ctx, "manage courses")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	err = validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.r.UpdateCourse(ctx, id, input)
+	if err != nil {
+		return nil, fmt.Errorf("error updating course from repository %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *service) DeleteCourse(ctx context.Context, id string) error {
+	err := policy.Admin(ctx, "manage courses")
+	if err != nil {
+		return fmt.Errorf("error authorising %w", err)
+	}
+
+	err = s.r.DeleteCourse(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting course from repository %w", err)
+	}
+
+	return nil
+}
+
+func validate(input model.CourseInput) error {
+	if input.Name == "" {
+		return invalidCourse("name", "course name can not be empty")
+	}
+
+	if len(input.Holes) != MaxHoles && len(input.Holes) != MaxHoles/2 {
+		return invalidCourse("holes", fmt.Sprintf("a course must have %d or %d holes", MaxHoles/2, MaxHoles))
+	}
+
+	numbers := make(map[int]bool, len(input.Holes))
+	strokeIndexes := make(map[int]bool, len(input.Holes))
+
+	for i, h := range input.Holes {
+		if h.Number < 1 || h.Number > len(input.Holes) || numbers[h.Number] {
+			return invalidCourse(fmt.Sprintf("holes[%d].number", i), fmt.Sprintf("invalid or duplicate hole number %d", h.Number))
+		}
+
+		if h.StrokeIndex < 1 || h.StrokeIndex > MaxHoles || strokeIndexes[h.StrokeIndex] {
+			return invalidCourse(fmt.Sprintf("holes[%d].strokeIndex", i), fmt.Sprintf("invalid or duplicate stroke index %d on hole %d", h.StrokeIndex, h.Number))
+		}
+
+		if h.Par < MinPar || h.Par > MaxPar {
+			return invalidCourse(fmt.Sprintf("holes[%d].par", i), fmt.Sprintf("invalid par %d on hole %d", h.Par, h.Number))
+		}
+
+		numbers[h.Number] = true
+		strokeIndexes[h.StrokeIndex] = true
+	}
+
+	if len(input.Tees) == 0 {
+		return invalidCourse("tees", "a course must have at least one tee")
+	}
+
+	tees := make(map[string]bool, len(input.Tees))
+
+	for i, t := range input.Tees {
+		if t.Name == "" || tees[t.Name] {
+			return invalidCourse(fmt.Sprintf("tees[%d].name", i), fmt.Sprintf("invalid or duplicate tee name %s", t.Name))
+		}
+
+		if t.CourseRating <= 0 {
+			return invalidCourse(fmt.Sprintf("tees[%d].courseRating", i), fmt.Sprintf("invalid course rating %.1f on tee %s", t.CourseRating, t.Name))
+		}
+
+		if t.Slope < MinSlope || t.Slope > MaxSlope {
+			return invalidCourse(fmt.Sprintf("tees[%d].slope", i), fmt.Sprintf("invalid slope %d on tee %s", t.Slope, t.Name))
+		}
+
+		tees[t.Name] = true
+	}
+
+	return nil
+}
+
+func invalidCourse(field, message string) error {
+	return ierrors.Invalid(field, message)
+}
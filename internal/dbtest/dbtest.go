@@ -0,0 +1,80 @@
+// Package dbtest provides migrated databases for tests running against the SQL repositories.
+package dbtest
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"tour-le-shit-go/internal/migrations"
+	"tour-le-shit-go/internal/sqlite"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// PostgresDsnKey the env variable holding the connection string of the Postgres test database.
+const PostgresDsnKey = "POSTGRES_TEST_DSN"
+
+// Sqlite returns a migrated SQLite database stored in a temporary directory of the test.
+func Sqlite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	database, err := sqlite.Open(t.TempDir() + "/tourleshit.db")
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	t.Cleanup(func() {
+		_ = database.Close()
+	})
+
+	return database
+}
+
+// Postgres returns a migrated Postgres database living in a schema of its own which is dropped
+// when the test ends. The test is skipped unless POSTGRES_TEST_DSN is set.
+func Postgres(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn, ok := os.LookupEnv(PostgresDsnKey)
+	if !ok {
+		t.Skipf("%s is not set", PostgresDsnKey)
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	schema := "test_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	_, err = admin.Exec(fmt.Sprintf("CREATE SCHEMA %s;", schema))
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	database, err := sql.Open("postgres", fmt.Sprintf("%s search_path=%s", dsn, schema))
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	t.Cleanup(func() {
+		_ = database.Close()
+		_, _ = admin.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE;", schema))
+		_ = admin.Close()
+	})
+
+	runner, err := migrations.NewRunner(database, migrations.Postgres)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	_, err = runner.Up()
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	return database
+}
@@ -3,10 +3,20 @@ package env
 import "os"
 
 type AppEnv struct {
-	MembersMode string
-	Port        string
-	ScoreMode   string
-	Db          Db
+	AuditMode      string
+	AuthMode       string
+	AuthSecret     string
+	CoursesMode    string
+	HallOfFameMode string
+	MembersMode    string
+	MockDataPath   string
+	Port           string
+	RetentionDays  string
+	ScoreMode      string
+	SeasonsMode    string
+	SqlitePath     string
+	Timezone       string
+	Db             Db
 }
 
 type Db struct {
@@ -32,9 +42,19 @@ func GetAppEnv() AppEnv {
 	}
 
 	return AppEnv{
-		MembersMode: getEnvVariable("MEMBERS_MODE"),
-		Port:        getEnvVariable("PORT"),
-		ScoreMode:   getEnvVariable("SCORE_MODE"),
-		Db:          db,
+		AuditMode:      getEnvVariable("AUDIT_MODE"),
+		AuthMode:       getEnvVariable("AUTH_MODE"),
+		AuthSecret:     getEnvVariable("AUTH_SECRET"),
+		CoursesMode:    getEnvVariable("COURSES_MODE"),
+		HallOfFameMode: getEnvVariable("HALL_OF_FAME_MODE"),
+		MembersMode:    getEnvVariable("MEMBERS_MODE"),
+		MockDataPath:   getEnvVariable("MOCK_DATA_PATH"),
+		Port:           getEnvVariable("PORT"),
+		RetentionDays:  getEnvVariable("RETENTION_DAYS"),
+		ScoreMode:      getEnvVariable("SCORE_MODE"),
+		SeasonsMode:    getEnvVariable("SEASONS_MODE"),
+		SqlitePath:     getEnvVariable("SQLITE_PATH"),
+		Timezone:       getEnvVariable("TIMEZONE"),
+		Db:             db,
 	}
 }
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/ierrors"
+)
+
+const GetFinalStandingsQuery = "SELECT season, name, closed_on FROM final_standings WHERE season = $1"
+const GetAllFinalStandingsQuery = "SELECT season, name, closed_on FROM final_standings ORDER BY season;"
+const GetStandingsQuery = `
+	SELECT season, position, shared, player_id, player_name, points, last_played
+	FROM final_standing WHERE season = $1 ORDER BY position, player_name;
+`
+const GetAllStandingsQuery = `
+	SELECT season, position, shared, player_id, player_name, points, last_played
+	FROM final_standing ORDER BY season, position, player_name;
+`
+const DeleteStandingsQuery = "DELETE FROM final_standing WHERE season = $1;"
+const DeleteFinalStandingsQuery = "DELETE FROM final_standings WHERE season = $1;"
+const InsertFinalStandingsQuery = "INSERT INTO final_standings (season, name, closed_on) VALUES ($1, $2, $3);"
+const InsertStandingQuery = `
+	INSERT INTO final_standing (season, position, shared, player_id, player_name, points, last_played)
+	VALUES ($1, $2, $3, $4, $5, $6, $7);
+`
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) GetFinalStandings(ctx context.Context, season int) (*model.FinalStandings, error) {
+	var f model.FinalStandings
+
+	err := r.db.QueryRowContext(ctx, GetFinalStandingsQuery, season).Scan(&f.Season, &f.Name, &f.ClosedOn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ierrors.Database("error scanning final standings", err)
+	}
+
+	f.ClosedOn = f.ClosedOn.UTC()
+
+	standings, err := r.queryStandings(ctx, GetStandingsQuery, season)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Standings = standings[season]
+
+	return &f, nil
+}
+
+func (r *PostgresRepository) GetAllFinalStandings(ctx context.Context) ([]model.FinalStandings, error) {
+	rows, err := r.db.QueryContext(ctx, GetAllFinalStandingsQuery)
+	if err != nil {
+		return nil, ierrors.Database("error fetching final standings", err)
+	}
+
+	defer rows.Close()
+
+	all := make([]model.FinalStandings, 0)
+
+	for rows.Next() {
+		var f model.FinalStandings
+
+		err = rows.Scan(&f.Season, &f.Name, &f.ClosedOn)
+		if err != nil {
+			return nil, ierrors.Database("error scanning rows", err)
+		}
+
+		f.ClosedOn = f.ClosedOn.UTC()
+
+		all = append(all, f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading final standings", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return nil, ierrors.Database("error closing final standings", err)
+	}
+
+	standings, err := r.queryStandings(ctx, GetAllStandingsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range all {
+		all[i].Standings = standings[all[i].Season]
+	}
+
+	return all, nil
+}
+
+// SaveFinalStandings replaces the final standings of the season if they were saved before.
+func (r *PostgresRepository) SaveFinalStandings(ctx context.Context, standings model.FinalStandings) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ierrors.Database("error starting transaction", err)
+	}
+
+	_, err = tx.ExecContext(ctx, DeleteStandingsQuery, standings.Season)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return ierrors.Database("error executing delete standings query", err)
+	}
+
+	_, err = tx.ExecContext(ctx, DeleteFinalStandingsQuery, standings.Season)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return ierrors.Database("error executing delete final standings query", err)
+	}
+
+	_, err = tx.ExecContext(ctx, InsertFinalStandingsQuery, standings.Season, standings.Name, standings.ClosedOn)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return ierrors.Database("error executing insert final standings query", err)
+	}
+
+	for _, s := range standings.Standings {
+		_, err = tx.ExecContext(ctx, InsertStandingQuery, standings.Season, s.Position, s.Shared, s.PlayerId, s.PlayerName, s.Points, s.LastPlayed)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return ierrors.Database("error executing insert standing query", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return ierrors.Database("error committing transaction", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) queryStandings(ctx context.Context, query string, args ...any) (map[int][]model.Standing, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ierrors.Database("error fetching standings", err)
+	}
+
+	defer rows.Close()
+
+	standings := make(map[int][]model.Standing, 0)
+
+	for rows.Next() {
+		var season int
+
+		var s model.Standing
+
+		err = rows.Scan(&season, &s.Position, &s.Shared, &s.PlayerId, &s.PlayerName, &s.Points, &s.LastPlayed)
+		if err != nil {
+			return nil, ierrors.Database("error scanning rows", err)
+		}
+
+		s.LastPlayed = s.LastPlayed.UTC()
+
+		standings[season] = append(standings[season], s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading standings", err)
+	}
+
+	return standings, nil
+}
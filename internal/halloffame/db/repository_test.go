@@ -0,0 +1,114 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/dbtest"
+	"tour-le-shit-go/internal/halloffame/db"
+	"tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/utils"
+)
+
+func TestSqliteRepository(t *testing.T) {
+	t.Parallel()
+
+	run(t, dbtest.Sqlite)
+}
+
+func TestPostgresRepository(t *testing.T) {
+	t.Parallel()
+
+	run(t, dbtest.Postgres)
+}
+
+func run(t *testing.T, open func(t *testing.T) *sql.DB) {
+	t.Helper()
+
+	standings := model.FinalStandings{
+		Season:   1,
+		Name:     "2022",
+		ClosedOn: day("2022-12-31"),
+		Standings: []model.Standing{
+			{Position: 1, Shared: true, PlayerId: "id1", PlayerName: "Alice", Points: 40, LastPlayed: day("2022-12-01")},
+			{Position: 1, Shared: true, PlayerId: "id2", PlayerName: "Bob", Points: 40, LastPlayed: day("2022-12-01")},
+			{Position: 3, PlayerId: "id3", PlayerName: "Carol", Points: 20, LastPlayed: day("2022-11-01")},
+		},
+	}
+
+	t.Run("saved final standings are returned", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := db.NewRepository(open(t))
+
+		err := r.SaveFinalStandings(context.Background(), standings)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		// act
+		result, err := r.GetFinalStandings(context.Background(), standings.Season)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if result == nil || !reflect.DeepEqual(*result, standings) {
+			t.Errorf("expected %v got %v", standings, result)
+		}
+
+		all, err := r.GetAllFinalStandings(context.Background())
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 1 || !reflect.DeepEqual(all[0], standings) {
+			t.Errorf("expected %v got %v", standings, all)
+		}
+	})
+	t.Run("saving again replaces the final standings", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := db.NewRepository(open(t))
+
+		err := r.SaveFinalStandings(context.Background(), standings)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		replaced := model.FinalStandings{
+			Season:    standings.Season,
+			Name:      standings.Name,
+			ClosedOn:  day("2023-01-01"),
+			Standings: []model.Standing{{Position: 1, PlayerId: "id1", PlayerName: "Alice", Points: 45, LastPlayed: day("2022-12-01")}},
+		}
+
+		// act
+		err = r.SaveFinalStandings(context.Background(), replaced)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		all, err := r.GetAllFinalStandings(context.Background())
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 1 || !reflect.DeepEqual(all[0], replaced) {
+			t.Errorf("expected %v got %v", replaced, all)
+		}
+	})
+}
+
+func day(value string) time.Time {
+	d, _ := utils.ParseDate(value)
+
+	return d
+}
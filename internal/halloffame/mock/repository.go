@@ -0,0 +1,86 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/utils"
+)
+
+// MockedRepository keeps the final standings in memory and is safe for concurrent use.
+type MockedRepository struct {
+	mu        sync.RWMutex
+	standings []model.FinalStandings
+}
+
+func NewRepository(standings []model.FinalStandings) *MockedRepository {
+	return &MockedRepository{standings: standings}
+}
+
+func (r *MockedRepository) GetFinalStandings(_ context.Context, season int) (*model.FinalStandings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, f := range r.standings {
+		if f.Season == season {
+			final := f
+
+			return &final, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockedRepository) GetAllFinalStandings(_ context.Context) ([]model.FinalStandings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.FinalStandings, len(r.standings))
+	copy(result, r.standings)
+
+	return result, nil
+}
+
+// SaveFinalStandings replaces the final standings of the season if they were saved before.
+func (r *MockedRepository) SaveFinalStandings(_ context.Context, standings model.FinalStandings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, f := range r.standings {
+		if f.Season == standings.Season {
+			r.standings[i] = standings
+
+			return nil
+		}
+	}
+
+	r.standings = append(r.standings, standings)
+
+	return nil
+}
+
+// Load replaces the final standings with the ones saved to path, nothing changes if the file is missing.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var standings []model.FinalStandings
+	if err := utils.ReadJson(path, &standings); err != nil {
+		return err
+	}
+
+	if standings != nil {
+		r.standings = standings
+	}
+
+	return nil
+}
+
+// Save writes the final standings to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, r.standings)
+}
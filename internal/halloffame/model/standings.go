@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// FinalStandings the frozen scoreboard of a closed season. ClosedOn is midnight UTC of the day
+// the season was closed.
+type FinalStandings struct {
+	Season    int
+	Name      string
+	ClosedOn  time.Time
+	Standings []Standing
+}
+
+// Standing the final position of a player in a closed season.
+type Standing struct {
+	Position   int
+	Shared     bool
+	PlayerId   string
+	PlayerName string
+	Points     int
+	LastPlayed time.Time
+}
+
+// Champion returns the winner of the season or nil if nobody played.
+func (f FinalStandings) Champion() *Standing {
+	if len(f.Standings) == 0 {
+		return nil
+	}
+
+	return &f.Standings[0]
+}
+
+// ShitHolder returns the player finishing last in the season or nil if nobody played.
+func (f FinalStandings) ShitHolder() *Standing {
+	if len(f.Standings) == 0 {
+		return nil
+	}
+
+	return &f.Standings[len(f.Standings)-1]
+}
@@ -0,0 +1,142 @@
+package halloffame
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/policy"
+	"tour-le-shit-go/internal/score"
+	scoreModel "tour-le-shit-go/internal/score/model"
+	"tour-le-shit-go/internal/seasons"
+	"tour-le-shit-go/internal/utils"
+)
+
+type Repository interface {
+	GetFinalStandings(ctx context.Context, season int) (*model.FinalStandings, error)
+	GetAllFinalStandings(ctx context.Context) ([]model.FinalStandings, error)
+	// SaveFinalStandings replaces the final standings of the season if they were saved before.
+	SaveFinalStandings(ctx context.Context, standings model.FinalStandings) error
+}
+
+type Service interface {
+	CloseSeason(ctx context.Context, season int) (*model.FinalStandings, error)
+	GetFinalStandings(ctx context.Context, season int) (*model.FinalStandings, error)
+	GetHallOfFame(ctx context.Context) ([]model.FinalStandings, error)
+}
+
+type service struct {
+	r       Repository
+	seasons seasons.Service
+	scores  score.Service
+	clock   utils.Clock
+}
+
+func NewService(r Repository, se seasons.Service, s score.Service, clock utils.Clock) Service {
+	return &service{r: r, seasons: se, scores: s, clock: clock}
+}
+
+// CloseSeason freezes the scoreboard of an open season and closes it for new scores.
+// Players without any rounds in the season are left out of the final standings and ties
+// are broken by the tiebreakers of the season. The final standings are saved before the season
+// is closed and saving replaces them, so closing again after the season could not be closed
+// takes a fresh snapshot instead of failing.
+func (s *service) CloseSeason(ctx context.Context, id int) (*model.FinalStandings, error) {
+	err := policy.Admin(ctx, "manage seasons")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	season, err := s.seasons.GetSeason(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching season %d %w", id, err)
+	}
+
+	if season == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("season %d does not exist", id))
+	}
+
+	if !season.IsOpen() {
+		return nil, ierrors.Conflict(fmt.Sprintf("season %d is already closed", id))
+	}
+
+	sb, err := s.scores.GetScoreboard(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching scoreboard of season %d %w", id, err)
+	}
+
+	rules, err := s.scores.GetRules(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rules of season %d %w", id, err)
+	}
+
+	played := make([]scoreModel.ScoreboardPlayer, 0)
+
+	for _, p := range sb.Players {
+		if len(p.Rounds) > 0 {
+			played = append(played, p)
+		}
+	}
+
+	positions := score.Rank(played, score.GrossPoints, rules)
+
+	standings := make([]model.Standing, 0)
+
+	for _, p := range played {
+		standings = append(standings, model.Standing{
+			Position:   positions[p.Id].Position,
+			Shared:     positions[p.Id].Shared,
+			PlayerId:   p.Id,
+			PlayerName: p.Name,
+			Points:     p.Points,
+			LastPlayed: p.LastPlayed,
+		})
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Position < standings[j].Position
+	})
+
+	final := model.FinalStandings{
+		Season:    season.Id,
+		Name:      season.Name,
+		ClosedOn:  s.clock.Today(),
+		Standings: standings,
+	}
+
+	err = s.r.SaveFinalStandings(ctx, final)
+	if err != nil {
+		return nil, fmt.Errorf("error saving final standings of season %d %w", id, err)
+	}
+
+	_, err = s.seasons.CloseSeason(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error closing season %d %w", id, err)
+	}
+
+	return &final, nil
+}
+
+func (s *service) GetFinalStandings(ctx context.Context, season int) (*model.FinalStandings, error) {
+	f, err := s.r.GetFinalStandings(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching final standings of season %d from repository %w", season, err)
+	}
+
+	return f, nil
+}
+
+// GetHallOfFame returns the final standings of every closed season, the latest first.
+func (s *service) GetHallOfFame(ctx context.Context) ([]model.FinalStandings, error) {
+	all, err := s.r.GetAllFinalStandings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching final standings from repository %w", err)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Season > all[j].Season
+	})
+
+	return all, nil
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Handicap the World Handicap System handicap index of a player together with its history.
+// The handicap index is nil until enough eligible rounds have been played.
+type Handicap struct {
+	PlayerId         string
+	HandicapIndex    *float64
+	LowHandicapIndex *float64
+	History          []Revision
+}
+
+// Revision the handicap index of a player after an eligible round.
+type Revision struct {
+	ScoreId            string
+	Day                time.Time
+	CourseId           string
+	Tee                string
+	AdjustedGrossScore int
+	Differential       float64
+	HandicapIndex      *float64
+}
@@ -0,0 +1,148 @@
+package handicap
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tour-le-shit-go/internal/courses"
+	coursesModel "tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/handicap/model"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/score"
+	scoreModel "tour-le-shit-go/internal/score/model"
+)
+
+type Service interface {
+	GetHandicap(ctx context.Context, playerId string) (*model.Handicap, error)
+	ApplyNet(ctx context.Context, sb scoreModel.Scoreboard) (scoreModel.Scoreboard, error)
+}
+
+type service struct {
+	players players.Service
+	scores  score.Service
+	courses courses.Service
+}
+
+func NewService(p players.Service, s score.Service, c courses.Service) Service {
+	return &service{players: p, scores: s, courses: c}
+}
+
+// GetHandicap returns the handicap of a player or nil if the player does not exist.
+func (s *service) GetHandicap(ctx context.Context, playerId string) (*model.Handicap, error) {
+	player, err := s.players.GetMember(ctx, playerId)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching player with id %s %w", playerId, err)
+	}
+
+	if player == nil {
+		return nil, nil
+	}
+
+	rounds, err := s.scores.GetPlayerRounds(ctx, playerId)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rounds of player with id %s %w", playerId, err)
+	}
+
+	courseById, err := s.getCourses(ctx, rounds)
+	if err != nil {
+		return nil, err
+	}
+
+	h := Calculate(playerId, rounds, courseById)
+
+	return &h, nil
+}
+
+// ApplyNet fills in the net points of every player on the scoreboard. Rounds entered hole by
+// hole with a handicap are played off that handicap, other rounds off the playing handicap
+// derived from the handicap index in effect when the round was played. Rounds with holes gain
+// the Stableford points of the strokes received on each hole, rounds entered as a total one
+// point per stroke. The rounds and courses are loaded once for the whole scoreboard.
+func (s *service) ApplyNet(ctx context.Context, sb scoreModel.Scoreboard) (scoreModel.Scoreboard, error) {
+	rounds, err := s.scores.GetRounds(ctx)
+	if err != nil {
+		return sb, fmt.Errorf("error fetching rounds %w", err)
+	}
+
+	all, err := s.courses.GetCourses(ctx)
+	if err != nil {
+		return sb, fmt.Errorf("error fetching courses %w", err)
+	}
+
+	courseById := make(map[string]coursesModel.Course, len(all))
+	for _, c := range all {
+		courseById[c.Id] = c
+	}
+
+	roundsByPlayer := make(map[string][]scoreModel.Score, len(sb.Players))
+	for _, r := range rounds {
+		roundsByPlayer[r.PlayerId] = append(roundsByPlayer[r.PlayerId], r)
+	}
+
+	for i, p := range sb.Players {
+		h := Calculate(p.Id, roundsByPlayer[p.Id], courseById)
+
+		sb.Players[i].NetPoints = p.Points
+		sb.Players[i].HandicapIndex = h.HandicapIndex
+
+		for _, round := range p.Rounds {
+			strokes := round.Handicap
+			if strokes == 0 {
+				strokes = PlayingHandicap(indexAt(h, round.Day), round, courseById)
+			}
+
+			sb.Players[i].NetPoints += pointsReceived(round, strokes)
+		}
+	}
+
+	return sb, nil
+}
+
+// pointsReceived the points the strokes received add to the gross points of a round.
+func pointsReceived(round scoreModel.Score, strokes int) int {
+	if len(round.Holes) == 0 {
+		return strokes
+	}
+
+	return score.StablefordPoints(round.Holes, strokes) - score.StablefordPoints(round.Holes, 0)
+}
+
+func (s *service) getCourses(ctx context.Context, rounds []scoreModel.Score) (map[string]coursesModel.Course, error) {
+	courseById := make(map[string]coursesModel.Course, 0)
+
+	for _, r := range rounds {
+		if r.CourseId == "" {
+			continue
+		}
+
+		if _, ok := courseById[r.CourseId]; ok {
+			continue
+		}
+
+		course, err := s.courses.GetCourse(ctx, r.CourseId)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching course with id %s %w", r.CourseId, err)
+		}
+
+		if course != nil {
+			courseById[r.CourseId] = *course
+		}
+	}
+
+	return courseById, nil
+}
+
+// indexAt returns the handicap index in effect before the given day.
+func indexAt(h model.Handicap, day time.Time) *float64 {
+	var index *float64
+
+	for _, r := range h.History {
+		if !r.Day.Before(day) {
+			break
+		}
+
+		index = r.HandicapIndex
+	}
+
+	return index
+}
@@ -0,0 +1,246 @@
+package handicap
+
+import (
+	"math"
+	"sort"
+	"time"
+	coursesModel "tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/handicap/model"
+	scoreModel "tour-le-shit-go/internal/score/model"
+)
+
+const MaxHandicapIndex = 54.0
+const StandardSlope = 113.0
+const MaxDifferentials = 20
+const SoftCapThreshold = 3.0
+const SoftCapReduction = 0.5
+const HardCapThreshold = 5.0
+const LowIndexWindow = 365 * 24 * time.Hour
+const HolesPerRound = 18
+const NetDoubleBogey = 2
+const MaxOverParWithoutHandicap = 5
+const PlayingHandicapAllowance = 0.95
+
+// CourseHandicap converts a handicap index to the number of strokes received on a tee.
+func CourseHandicap(index float64, tee coursesModel.Tee, par int) int {
+	return int(math.Round(index*float64(tee.Slope)/StandardSlope + tee.CourseRating - float64(par)))
+}
+
+// PlayingHandicap the strokes received in a round for a handicap index. Rounds played on a
+// known course and tee use the course handicap, otherwise the handicap index is used as is.
+func PlayingHandicap(index *float64, round scoreModel.Score, courses map[string]coursesModel.Course) int {
+	if index == nil {
+		return 0
+	}
+
+	strokes := *index
+
+	if course, ok := courses[round.CourseId]; ok {
+		if tee := course.Tee(round.Tee); tee != nil {
+			if len(course.Holes) < HolesPerRound {
+				strokes = float64(CourseHandicap(*index/2, *tee, course.Par()))
+			} else {
+				strokes = float64(CourseHandicap(*index, *tee, course.Par()))
+			}
+		}
+	}
+
+	return int(math.Round(strokes * PlayingHandicapAllowance))
+}
+
+// AdjustedGrossScore sums the strokes of a round where every hole is capped at net double bogey.
+// Players without a handicap index are capped at par plus five.
+func AdjustedGrossScore(holes []scoreModel.Hole, courseHandicap *int) int {
+	total := 0
+
+	for _, h := range holes {
+		limit := h.Par + MaxOverParWithoutHandicap
+		if courseHandicap != nil {
+			limit = h.Par + NetDoubleBogey + strokesReceived(*courseHandicap, h.StrokeIndex)
+		}
+
+		if h.Strokes > limit {
+			total += limit
+		} else {
+			total += h.Strokes
+		}
+	}
+
+	return total
+}
+
+// ScoreDifferential computes the score differential of an adjusted gross score on a tee.
+func ScoreDifferential(adjustedGrossScore int, tee coursesModel.Tee) float64 {
+	return roundTenth(StandardSlope / float64(tee.Slope) * (float64(adjustedGrossScore) - tee.CourseRating))
+}
+
+// Calculate computes the handicap history of a player from the rounds ordered by day.
+// Only 18 hole rounds played on a known course and tee are eligible.
+func Calculate(playerId string, rounds []scoreModel.Score, courses map[string]coursesModel.Course) model.Handicap {
+	result := model.Handicap{
+		PlayerId:         playerId,
+		HandicapIndex:    nil,
+		LowHandicapIndex: nil,
+		History:          make([]model.Revision, 0),
+	}
+
+	differentials := make([]float64, 0)
+
+	for _, round := range rounds {
+		course, ok := courses[round.CourseId]
+		if !ok || len(round.Holes) != HolesPerRound {
+			continue
+		}
+
+		tee := course.Tee(round.Tee)
+		if tee == nil {
+			continue
+		}
+
+		var courseHandicap *int
+
+		if result.HandicapIndex != nil {
+			ch := CourseHandicap(*result.HandicapIndex, *tee, course.Par())
+			courseHandicap = &ch
+		}
+
+		ags := AdjustedGrossScore(round.Holes, courseHandicap)
+		differential := ScoreDifferential(ags, *tee)
+		differentials = append(differentials, differential)
+
+		index := handicapIndex(differentials)
+		if index != nil && len(differentials) >= MaxDifferentials {
+			low := lowHandicapIndex(result.History, round.Day)
+			if low != nil {
+				capped := applyCaps(*index, *low)
+				index = &capped
+			}
+		}
+
+		result.History = append(result.History, model.Revision{
+			ScoreId:            round.Id,
+			Day:                round.Day,
+			CourseId:           round.CourseId,
+			Tee:                round.Tee,
+			AdjustedGrossScore: ags,
+			Differential:       differential,
+			HandicapIndex:      index,
+		})
+		result.HandicapIndex = index
+	}
+
+	if len(result.History) > 0 {
+		result.LowHandicapIndex = lowHandicapIndex(result.History, result.History[len(result.History)-1].Day)
+	}
+
+	return result
+}
+
+// handicapIndex averages the best differentials of the most recent 20 according to the
+// number of differentials available.
+func handicapIndex(differentials []float64) *float64 {
+	recent := differentials
+	if len(recent) > MaxDifferentials {
+		recent = recent[len(recent)-MaxDifferentials:]
+	}
+
+	count, adjustment := differentialsToUse(len(recent))
+	if count == 0 {
+		return nil
+	}
+
+	best := make([]float64, len(recent))
+	copy(best, recent)
+	sort.Float64s(best)
+
+	sum := 0.0
+	for _, d := range best[:count] {
+		sum += d
+	}
+
+	index := math.Min(roundTenth(sum/float64(count)+adjustment), MaxHandicapIndex)
+
+	return &index
+}
+
+func differentialsToUse(available int) (count int, adjustment float64) {
+	switch {
+	case available < 3:
+		return 0, 0
+	case available == 3:
+		return 1, -2.0
+	case available == 4:
+		return 1, -1.0
+	case available == 5:
+		return 1, 0
+	case available == 6:
+		return 2, -1.0
+	case available <= 8:
+		return 2, 0
+	case available <= 11:
+		return 3, 0
+	case available <= 14:
+		return 4, 0
+	case available <= 16:
+		return 5, 0
+	case available <= 18:
+		return 6, 0
+	case available == 19:
+		return 7, 0
+	default:
+		return 8, 0
+	}
+}
+
+// lowHandicapIndex returns the lowest handicap index in the year leading up to the day.
+func lowHandicapIndex(history []model.Revision, day time.Time) *float64 {
+	var low *float64
+
+	for _, r := range history {
+		if r.HandicapIndex == nil || day.Sub(r.Day) > LowIndexWindow {
+			continue
+		}
+
+		if low == nil || *r.HandicapIndex < *low {
+			index := *r.HandicapIndex
+			low = &index
+		}
+	}
+
+	return low
+}
+
+// applyCaps limits the increase of a handicap index above the low handicap index by the
+// soft cap of 50% above 3.0 and the hard cap of 5.0.
+func applyCaps(index, low float64) float64 {
+	if index-low > SoftCapThreshold {
+		index = low + SoftCapThreshold + (index-low-SoftCapThreshold)*SoftCapReduction
+	}
+
+	if index-low > HardCapThreshold {
+		index = low + HardCapThreshold
+	}
+
+	return roundTenth(index)
+}
+
+func strokesReceived(courseHandicap, strokeIndex int) int {
+	if courseHandicap < 0 {
+		if strokeIndex > HolesPerRound+courseHandicap%HolesPerRound {
+			return courseHandicap/HolesPerRound - 1
+		}
+
+		return courseHandicap / HolesPerRound
+	}
+
+	received := courseHandicap / HolesPerRound
+	if strokeIndex <= courseHandicap%HolesPerRound {
+		received++
+	}
+
+	return received
+}
+
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -1,37 +1,121 @@
 package ierrors
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 )
 
-const BadRequestStatusCode = 400
-const ServerErrorStatusCode = 500
+// Kind classifies an error of the domain, the server maps every kind to a status code.
+type Kind int
 
-type HttpError struct {
-	Code       int    `json:"status"`
-	Message    string `json:"detail"`
-	InnerError string `json:"-"`
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnavailable
+	KindUnauthorized
+	KindForbidden
+)
+
+// Error is an error of the domain, Message is safe to show to the client.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError tells what is wrong with a single field of the input.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type DbError struct {
 	Message string
 }
 
-func (h HttpError) Error() string {
-	return fmt.Sprintf("message: %s innerError: %s", h.Message, h.InnerError)
+func (e Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s %v", e.Message, e.Err)
+	}
+
+	return e.Message
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
 }
 
 func (d DbError) Error() string {
 	return d.Message
 }
 
-func (h HttpError) PrintBody() []byte {
-	b, err := json.Marshal(h)
+func NotFound(message string) error {
+	return Error{Kind: KindNotFound, Message: message}
+}
+
+func Conflict(message string) error {
+	return Error{Kind: KindConflict, Message: message}
+}
+
+// Unauthorized is returned when the request lacks valid credentials.
+func Unauthorized(message string) error {
+	return Error{Kind: KindUnauthorized, Message: message}
+}
+
+// Forbidden is returned when the user is not allowed to do what the request asks for.
+func Forbidden(message string) error {
+	return Error{Kind: KindForbidden, Message: message}
+}
+
+func Validation(message string, fields ...FieldError) error {
+	return Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+// Invalid is a validation error of a single field.
+func Invalid(field, message string) error {
+	return Validation(message, FieldError{Field: field, Message: message})
+}
+
+// InvalidBody is a validation error of a request body that could not be decoded, the field is
+// included when the body has a value of the wrong type.
+func InvalidBody(err error) error {
+	e := Error{Kind: KindValidation, Message: "invalid request body", Err: err}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		e.Fields = []FieldError{{Field: typeError.Field, Message: fmt.Sprintf("expected %s", typeError.Type)}}
+	}
+
+	return e
+}
+
+// Database wraps an error of the database. Errors caused by an expired deadline or a lost
+// connection make the database unavailable, anything else is a DbError.
+func Database(message string, err error) error {
+	var netError net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netError) {
+		return Error{Kind: KindUnavailable, Message: "the database is unavailable, please try again later", Err: err}
+	}
+
+	return DbError{Message: fmt.Sprintf("%s %v", message, err)}
+}
 
-	if err != nil {
-		return []byte("unknown error")
+// KindOf returns the kind of the first Error in the chain of err.
+func KindOf(err error) Kind {
+	var e Error
+	if errors.As(err, &e) {
+		return e.Kind
 	}
 
-	return b
+	return KindInternal
 }
@@ -0,0 +1,271 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"tour-le-shit-go/internal/ierrors"
+)
+
+const CreateMigrationsTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT,
+		name VARCHAR(150),
+		checksum VARCHAR(64),
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY(version)
+	);
+`
+
+const GetAppliedMigrationsQuery = `SELECT version, name, checksum FROM schema_migrations ORDER BY version;`
+
+const InsertMigrationQuery = `INSERT INTO schema_migrations (version, name, checksum) VALUES($1, $2, $3);`
+
+const DeleteMigrationQuery = `DELETE FROM schema_migrations WHERE version=$1;`
+
+// Postgres and Sqlite the supported dialects, each with its own directory of migrations.
+const Postgres = "postgres"
+const Sqlite = "sqlite"
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration a versioned change of the schema together with the statements reverting it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum the sha256 of the up statements, used to detect migrations changed after being applied.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+
+	return hex.EncodeToString(sum[:])
+}
+
+type applied struct {
+	version  int
+	name     string
+	checksum string
+}
+
+// Runner applies the embedded migrations of a dialect in order and keeps track of them in
+// the schema_migrations table.
+type Runner struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewRunner(db *sql.DB, dialect string) (*Runner, error) {
+	migrations, err := load(files, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration after verifying that the applied ones are unchanged.
+// Returns the migrations that were applied.
+func (r *Runner) Up() ([]Migration, error) {
+	done, err := r.verify()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0)
+
+	for _, m := range r.migrations[len(done):] {
+		err = r.apply(m.Up, InsertMigrationQuery, m.Version, m.Name, m.Checksum())
+		if err != nil {
+			return result, fmt.Errorf("error applying migration %d_%s %w", m.Version, m.Name, err)
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// Down reverts the latest applied migration. Returns nil if no migration has been applied.
+func (r *Runner) Down() (*Migration, error) {
+	done, err := r.verify()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(done) == 0 {
+		return nil, nil
+	}
+
+	m := r.migrations[len(done)-1]
+
+	err = r.apply(m.Down, DeleteMigrationQuery, m.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error reverting migration %d_%s %w", m.Version, m.Name, err)
+	}
+
+	return &m, nil
+}
+
+// verify returns the applied migrations after checking that they are the first embedded
+// migrations and that none of them changed since being applied.
+func (r *Runner) verify() ([]applied, error) {
+	_, err := r.db.Exec(CreateMigrationsTableQuery)
+	if err != nil {
+		return nil, ierrors.DbError{
+			Message: "Error creating migrations table " + err.Error(),
+		}
+	}
+
+	rows, err := r.db.Query(GetAppliedMigrationsQuery)
+	if err != nil {
+		return nil, ierrors.DbError{
+			Message: "Error fetching applied migrations " + err.Error(),
+		}
+	}
+
+	defer rows.Close()
+
+	done := make([]applied, 0)
+
+	for rows.Next() {
+		var a applied
+
+		err = rows.Scan(&a.version, &a.name, &a.checksum)
+		if err != nil {
+			return nil, ierrors.DbError{
+				Message: "error trying to scan rows " + err.Error(),
+			}
+		}
+
+		done = append(done, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.DbError{
+			Message: "error reading applied migrations " + err.Error(),
+		}
+	}
+
+	for i, a := range done {
+		if i >= len(r.migrations) || r.migrations[i].Version != a.version {
+			return nil, ierrors.DbError{
+				Message: fmt.Sprintf("applied migration %d_%s is unknown", a.version, a.name),
+			}
+		}
+
+		if r.migrations[i].Checksum() != a.checksum {
+			return nil, ierrors.DbError{
+				Message: fmt.Sprintf("migration %d_%s changed after being applied", a.version, a.name),
+			}
+		}
+	}
+
+	return done, nil
+}
+
+// apply runs the statements of a migration and records it in a single transaction.
+func (r *Runner) apply(statements, record string, args ...any) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return ierrors.DbError{
+			Message: "Error starting transaction " + err.Error(),
+		}
+	}
+
+	_, err = tx.Exec(statements)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return ierrors.DbError{
+			Message: "Error executing migration " + err.Error(),
+		}
+	}
+
+	_, err = tx.Exec(record, args...)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return ierrors.DbError{
+			Message: "Error recording migration " + err.Error(),
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return ierrors.DbError{
+			Message: "Error committing transaction " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// load reads the migrations of a directory ordered by version. Every migration must have
+// both an up and a down file.
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations %w", err)
+	}
+
+	byVersion := make(map[int]*Migration, 0)
+
+	for _, e := range entries {
+		match := fileNamePattern.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", e.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s %w", e.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d", version)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both an up and a down file", m.Version, m.Name)
+		}
+
+		result = append(result, *m)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
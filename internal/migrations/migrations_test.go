@@ -0,0 +1,490 @@
+package migrations
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func newDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	database, err := sql.Open("sqlite", "file:"+t.TempDir()+"/migrations.db")
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	database.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = database.Close()
+	})
+
+	return database
+}
+
+func newMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "create_first", Up: "CREATE TABLE first (id INT);", Down: "DROP TABLE first;"},
+		{Version: 2, Name: "create_second", Up: "CREATE TABLE second (id INT);", Down: "DROP TABLE second;"},
+	}
+}
+
+func tableExists(t *testing.T, database *sql.DB, name string) bool {
+	t.Helper()
+
+	var count int
+
+	err := database.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=$1;", name).Scan(&count)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	return count == 1
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("migrations are ordered by version", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		fsys := fstest.MapFS{
+			"dir/010_tenth.up.sql":    {Data: []byte("up 10")},
+			"dir/010_tenth.down.sql":  {Data: []byte("down 10")},
+			"dir/002_second.up.sql":   {Data: []byte("up 2")},
+			"dir/002_second.down.sql": {Data: []byte("down 2")},
+			"dir/001_first.up.sql":    {Data: []byte("up 1")},
+			"dir/001_first.down.sql":  {Data: []byte("down 1")},
+		}
+
+		// act
+		result, err := load(fsys, "dir")
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := []int{1, 2, 10}
+		if len(result) != len(expected) {
+			t.Fatalf("expected %d got %d", len(expected), len(result))
+		}
+
+		for i, m := range result {
+			if m.Version != expected[i] {
+				t.Errorf("expected %d got %d", expected[i], m.Version)
+			}
+		}
+
+		if result[2].Up != "up 10" || result[2].Down != "down 10" {
+			t.Errorf("expected %s got %s", "up 10 and down 10", result[2].Up+" and "+result[2].Down)
+		}
+	})
+	t.Run("migration without a down file is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		fsys := fstest.MapFS{
+			"dir/001_first.up.sql": {Data: []byte("up 1")},
+		}
+
+		// act
+		_, err := load(fsys, "dir")
+
+		// assert
+		if err == nil {
+			t.Errorf("expected error got none")
+		}
+	})
+	t.Run("embedded migrations are numbered without gaps", func(t *testing.T) {
+		t.Parallel()
+
+		for _, dialect := range []string{Postgres, Sqlite} {
+			// act
+			result, err := load(files, dialect)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			for i, m := range result {
+				if m.Version != i+1 {
+					t.Errorf("expected %d got %d for %s", i+1, m.Version, dialect)
+				}
+			}
+		}
+	})
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("up applies pending migrations in order", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+		all := newMigrations()
+		runner := &Runner{db: database, migrations: all[:1]}
+
+		_, err := runner.Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		runner = &Runner{db: database, migrations: all}
+
+		// act
+		result, err := runner.Up()
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(result) != 1 || result[0].Version != 2 {
+			t.Errorf("expected %s got %v", "only migration 2", result)
+		}
+
+		if !tableExists(t, database, "first") || !tableExists(t, database, "second") {
+			t.Errorf("expected %s got %s", "both tables", "a missing table")
+		}
+
+		result, err = runner.Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(result) != 0 {
+			t.Errorf("expected %d got %d", 0, len(result))
+		}
+	})
+	t.Run("failing migration is not recorded", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+		all := newMigrations()
+		all[1].Up = "CREATE TABLE second (id INT); NOT SQL;"
+		runner := &Runner{db: database, migrations: all}
+
+		// act
+		result, err := runner.Up()
+
+		// assert
+		if err == nil {
+			t.Fatalf("expected error got none")
+		}
+
+		if len(result) != 1 {
+			t.Errorf("expected %d got %d", 1, len(result))
+		}
+
+		done, err := runner.verify()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(done) != 1 {
+			t.Errorf("expected %d got %d", 1, len(done))
+		}
+	})
+	t.Run("changed migration is refused", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+
+		_, err := (&Runner{db: database, migrations: newMigrations()}).Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		changed := newMigrations()
+		changed[0].Up = "CREATE TABLE first (id INT, name TEXT);"
+		runner := &Runner{db: database, migrations: changed}
+
+		// act
+		_, err = runner.Up()
+
+		// assert
+		if err == nil || !strings.Contains(err.Error(), "changed after being applied") {
+			t.Errorf("expected %s got %v", "changed after being applied", err)
+		}
+
+		_, err = runner.Down()
+		if err == nil {
+			t.Errorf("expected error got none")
+		}
+	})
+	t.Run("unknown applied migration is refused", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+
+		_, err := (&Runner{db: database, migrations: newMigrations()}).Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		runner := &Runner{db: database, migrations: newMigrations()[:1]}
+
+		// act
+		_, err = runner.Up()
+
+		// assert
+		if err == nil || !strings.Contains(err.Error(), "applied migration 2_create_second is unknown") {
+			t.Errorf("expected %s got %v", "applied migration 2_create_second is unknown", err)
+		}
+	})
+	t.Run("down reverts the latest applied migration", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+		runner := &Runner{db: database, migrations: newMigrations()}
+
+		_, err := runner.Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		// act
+		reverted, err := runner.Down()
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if reverted == nil || reverted.Version != 2 {
+			t.Fatalf("expected %d got %v", 2, reverted)
+		}
+
+		if !tableExists(t, database, "first") || tableExists(t, database, "second") {
+			t.Errorf("expected %s got %s", "only the first table", "otherwise")
+		}
+
+		done, err := runner.verify()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(done) != 1 || done[0].version != 1 {
+			t.Errorf("expected %s got %v", "only migration 1 applied", done)
+		}
+
+		_, err = runner.Down()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		reverted, err = runner.Down()
+		if err != nil || reverted != nil {
+			t.Errorf("expected %s got %v, %v", "nothing to revert", reverted, err)
+		}
+	})
+	t.Run("embedded sqlite migrations can be applied and reverted", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+
+		runner, err := NewRunner(database, Sqlite)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_, err = runner.Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		// act
+		for {
+			reverted, err := runner.Down()
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			if reverted == nil {
+				break
+			}
+		}
+
+		// assert
+		if tableExists(t, database, "score") {
+			t.Errorf("expected %s got %s", "no score table", "a score table")
+		}
+	})
+	t.Run("gross points migration recomputes rounds entered with a handicap", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+
+		all, err := load(files, Sqlite)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		gross := 0
+
+		for i, m := range all {
+			if m.Name == "gross_points" {
+				gross = i
+			}
+		}
+
+		_, err = (&Runner{db: database, migrations: all[:gross]}).Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		statements := []string{
+			"INSERT INTO player (id, name) VALUES ('p', 'Alice');",
+			`INSERT INTO score (id, player_id, points, birdies, eagles, muligans, day, season, handicap, created_at, updated_at)
+				VALUES ('net', 'p', 9, 1, 1, 0, '2022-01-01', 1, 18, '2022-01-01', '2022-01-01'),
+				('total', 'p', 30, 0, 0, 0, '2022-01-01', 1, 0, '2022-01-01', '2022-01-01');`,
+			`INSERT INTO hole_score (score_id, hole, par, stroke_index, strokes)
+				VALUES ('net', 1, 4, 1, 3), ('net', 2, 5, 2, 3), ('net', 3, 3, 3, 7);`,
+		}
+
+		for _, statement := range statements {
+			_, err = database.Exec(statement)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+		}
+
+		runner := &Runner{db: database, migrations: all[:gross+1]}
+
+		points := func(id string) int {
+			t.Helper()
+
+			var p int
+
+			err := database.QueryRow("SELECT points FROM score WHERE id = $1;", id).Scan(&p)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			return p
+		}
+
+		// act
+		_, err = runner.Up()
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if points("net") != 7 || points("total") != 30 {
+			t.Errorf("expected %d and %d got %d and %d", 7, 30, points("net"), points("total"))
+		}
+
+		_, err = runner.Down()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if points("net") != 9 || points("total") != 30 {
+			t.Errorf("expected %d and %d got %d and %d", 9, 30, points("net"), points("total"))
+		}
+	})
+
+	t.Run("season dates migration keeps the dates of seasons and final standings", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database := newDatabase(t)
+
+		all, err := load(files, Sqlite)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		dates := 0
+
+		for i, m := range all {
+			if m.Name == "season_dates" {
+				dates = i
+			}
+		}
+
+		_, err = (&Runner{db: database, migrations: all[:dates]}).Up()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		statements := []string{
+			"INSERT INTO season (id, name, start_date, end_date, status) VALUES (1, 'June', '2022-06-01', '2022-06-30', 'CLOSED');",
+			"INSERT INTO final_standings (season, name, closed_on) VALUES (1, 'June', '2022-07-01');",
+			`INSERT INTO final_standing (season, position, shared, player_id, player_name, points, last_played)
+				VALUES (1, 1, false, 'p', 'Alice', 30, '2022-06-15');`,
+		}
+
+		for _, statement := range statements {
+			_, err = database.Exec(statement)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+		}
+
+		runner := &Runner{db: database, migrations: all[:dates+1]}
+		expected := []time.Time{
+			time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC),
+		}
+
+		// act
+		_, err = runner.Up()
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		actual := make([]time.Time, 4)
+
+		err = database.QueryRow(`SELECT s.start_date, s.end_date, f.closed_on, p.last_played
+			FROM season s JOIN final_standings f ON f.season = s.id JOIN final_standing p ON p.season = f.season;`).
+			Scan(&actual[0], &actual[1], &actual[2], &actual[3])
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		for i := range expected {
+			if !actual[i].Equal(expected[i]) {
+				t.Errorf("expected %v got %v", expected[i], actual[i])
+			}
+		}
+
+		_, err = runner.Down()
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var closedOn string
+
+		err = database.QueryRow("SELECT closed_on FROM final_standings WHERE season = 1;").Scan(&closedOn)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if closedOn != "2022-07-01" {
+			t.Errorf("expected %s got %s", "2022-07-01", closedOn)
+		}
+	})
+}
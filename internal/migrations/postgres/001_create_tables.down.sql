@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS final_standing;
+DROP TABLE IF EXISTS final_standings;
+DROP TABLE IF EXISTS season;
+DROP TABLE IF EXISTS hole_score;
+DROP TABLE IF EXISTS rules;
+DROP TABLE IF EXISTS score;
+DROP TABLE IF EXISTS course_tee;
+DROP TABLE IF EXISTS course_hole;
+DROP TABLE IF EXISTS course;
+DROP TABLE IF EXISTS player;
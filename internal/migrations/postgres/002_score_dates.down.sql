@@ -0,0 +1,4 @@
+ALTER TABLE score DROP COLUMN updated_at;
+ALTER TABLE score DROP COLUMN created_at;
+ALTER TABLE score ALTER COLUMN day DROP NOT NULL;
+ALTER TABLE score ALTER COLUMN day TYPE VARCHAR(10) USING to_char(day, 'YYYY-MM-DD');
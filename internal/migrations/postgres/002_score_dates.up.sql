@@ -0,0 +1,10 @@
+ALTER TABLE score ALTER COLUMN day TYPE DATE USING day::DATE;
+ALTER TABLE score ALTER COLUMN day SET NOT NULL;
+ALTER TABLE score ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE score ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+UPDATE score SET created_at = day WHERE created_at IS NULL;
+UPDATE score SET updated_at = day WHERE updated_at IS NULL;
+ALTER TABLE score ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE score ALTER COLUMN created_at SET DEFAULT now();
+ALTER TABLE score ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE score ALTER COLUMN updated_at SET DEFAULT now();
@@ -0,0 +1,2 @@
+DROP INDEX score_player_season_idx;
+ALTER TABLE score DROP CONSTRAINT score_pkey;
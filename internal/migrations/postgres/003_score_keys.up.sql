@@ -0,0 +1,2 @@
+ALTER TABLE score ADD PRIMARY KEY (id);
+CREATE INDEX score_player_season_idx ON score (player_id, season);
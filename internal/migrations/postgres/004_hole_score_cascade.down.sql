@@ -0,0 +1 @@
+ALTER TABLE hole_score DROP CONSTRAINT hole_score_score_id_fkey;
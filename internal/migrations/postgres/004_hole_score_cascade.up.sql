@@ -0,0 +1,2 @@
+DELETE FROM hole_score WHERE score_id NOT IN (SELECT id FROM score);
+ALTER TABLE hole_score ADD CONSTRAINT hole_score_score_id_fkey FOREIGN KEY (score_id) REFERENCES score(id) ON DELETE CASCADE;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS audit_entry (
+	seq BIGSERIAL,
+	id VARCHAR(36) NOT NULL,
+	entity VARCHAR(20) NOT NULL,
+	entity_id VARCHAR(36) NOT NULL,
+	action VARCHAR(20) NOT NULL,
+	actor VARCHAR(150) NOT NULL,
+	at TIMESTAMP NOT NULL,
+	before_value TEXT NOT NULL,
+	after_value TEXT NOT NULL,
+	PRIMARY KEY(seq),
+	UNIQUE(id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_entry_at ON audit_entry(at);
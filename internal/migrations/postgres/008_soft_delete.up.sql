@@ -0,0 +1,5 @@
+ALTER TABLE player ADD COLUMN deleted BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE player ADD COLUMN deleted_at TIMESTAMP;
+
+ALTER TABLE score ADD COLUMN deleted BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE score ADD COLUMN deleted_at TIMESTAMP;
@@ -0,0 +1 @@
+ALTER TABLE player DROP COLUMN archived_from;
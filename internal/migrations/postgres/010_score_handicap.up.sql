@@ -0,0 +1 @@
+ALTER TABLE score ADD COLUMN handicap INTEGER NOT NULL DEFAULT 0;
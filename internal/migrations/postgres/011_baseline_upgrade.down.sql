@@ -0,0 +1,2 @@
+-- The columns and keys are part of the schema created by 001_create_tables, so there is
+-- nothing to revert.
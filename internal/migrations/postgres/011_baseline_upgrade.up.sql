@@ -0,0 +1,22 @@
+-- Databases created from tables.sql before migrations existed were only ever given the tables
+-- missing from them by 001_create_tables, so the columns and keys added to existing tables
+-- in the meantime are added here.
+ALTER TABLE score ADD COLUMN IF NOT EXISTS course_id VARCHAR(36);
+ALTER TABLE score ADD COLUMN IF NOT EXISTS tee VARCHAR(50);
+
+DELETE FROM score WHERE player_id NOT IN (SELECT id FROM player);
+ALTER TABLE score DROP CONSTRAINT IF EXISTS score_player_id_fkey;
+ALTER TABLE score ADD CONSTRAINT score_player_id_fkey FOREIGN KEY (player_id) REFERENCES player(id) ON DELETE CASCADE;
+
+UPDATE score SET course_id = NULL WHERE course_id NOT IN (SELECT id FROM course);
+ALTER TABLE score DROP CONSTRAINT IF EXISTS score_course_id_fkey;
+ALTER TABLE score ADD CONSTRAINT score_course_id_fkey FOREIGN KEY (course_id) REFERENCES course(id) ON DELETE SET NULL;
+
+ALTER TABLE rules ADD COLUMN IF NOT EXISTS tiebreakers VARCHAR(200);
+ALTER TABLE rules ADD COLUMN IF NOT EXISTS countback_rounds INT;
+ALTER TABLE rules ADD COLUMN IF NOT EXISTS best_rounds INT;
+ALTER TABLE rules ADD COLUMN IF NOT EXISTS min_rounds INT;
+
+ALTER TABLE final_standing ADD COLUMN IF NOT EXISTS shared BOOLEAN;
+ALTER TABLE final_standing DROP CONSTRAINT IF EXISTS final_standing_pkey;
+ALTER TABLE final_standing ADD PRIMARY KEY (season, player_id);
@@ -0,0 +1,2 @@
+DROP INDEX app_user_first_user_key;
+ALTER TABLE app_user DROP COLUMN first_user;
@@ -0,0 +1,6 @@
+-- Rounds entered hole by hole with a handicap were stored with net points, the points are now
+-- always gross and the handicap is only applied on the net scoreboard.
+UPDATE score SET points = (
+	SELECT COALESCE(SUM(GREATEST(2 + h.par - h.strokes, 0)), 0) FROM hole_score h WHERE h.score_id = score.id
+)
+WHERE handicap > 0 AND EXISTS (SELECT 1 FROM hole_score h WHERE h.score_id = score.id);
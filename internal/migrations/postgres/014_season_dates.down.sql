@@ -0,0 +1,4 @@
+ALTER TABLE final_standing ALTER COLUMN last_played TYPE VARCHAR(10) USING to_char(last_played, 'YYYY-MM-DD');
+ALTER TABLE final_standings ALTER COLUMN closed_on TYPE VARCHAR(10) USING to_char(closed_on, 'YYYY-MM-DD');
+ALTER TABLE season ALTER COLUMN end_date TYPE VARCHAR(10) USING to_char(end_date, 'YYYY-MM-DD');
+ALTER TABLE season ALTER COLUMN start_date TYPE VARCHAR(10) USING to_char(start_date, 'YYYY-MM-DD');
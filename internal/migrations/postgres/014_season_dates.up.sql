@@ -0,0 +1,4 @@
+ALTER TABLE season ALTER COLUMN start_date TYPE DATE USING start_date::DATE;
+ALTER TABLE season ALTER COLUMN end_date TYPE DATE USING end_date::DATE;
+ALTER TABLE final_standings ALTER COLUMN closed_on TYPE DATE USING closed_on::DATE;
+ALTER TABLE final_standing ALTER COLUMN last_played TYPE DATE USING last_played::DATE;
@@ -0,0 +1,101 @@
+CREATE TABLE IF NOT EXISTS player (
+	id VARCHAR(36),
+	name VARCHAR(150),
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS course (
+	id VARCHAR(36),
+	name VARCHAR(150),
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS course_hole (
+	course_id VARCHAR(36),
+	hole INT,
+	par INT,
+	stroke_index INT,
+	PRIMARY KEY(course_id, hole),
+	FOREIGN KEY(course_id) REFERENCES course(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS course_tee (
+	course_id VARCHAR(36),
+	name VARCHAR(50),
+	course_rating NUMERIC(4, 1),
+	slope INT,
+	PRIMARY KEY(course_id, name),
+	FOREIGN KEY(course_id) REFERENCES course(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS score (
+	id VARCHAR(36),
+	player_id VARCHAR(36),
+	points INT,
+	birdies INT,
+	eagles INT,
+	muligans INT,
+	day DATE NOT NULL,
+	season INT,
+	course_id VARCHAR(36),
+	tee VARCHAR(50),
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY(id),
+	FOREIGN KEY(player_id) REFERENCES player(id) ON DELETE CASCADE,
+	FOREIGN KEY(course_id) REFERENCES course(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS score_player_season_idx ON score (player_id, season);
+
+CREATE TABLE IF NOT EXISTS rules (
+	season INT,
+	birdie_multiplier INT,
+	eagle_multiplier INT,
+	muligan_diminisher INT,
+	max_birdies INT,
+	max_eagles INT,
+	max_round_points INT,
+	tiebreakers VARCHAR(200),
+	countback_rounds INT,
+	best_rounds INT,
+	min_rounds INT,
+	PRIMARY KEY(season)
+);
+
+CREATE TABLE IF NOT EXISTS hole_score (
+	score_id VARCHAR(36),
+	hole INT,
+	par INT,
+	stroke_index INT,
+	strokes INT,
+	PRIMARY KEY(score_id, hole)
+);
+
+CREATE TABLE IF NOT EXISTS season (
+	id INT,
+	name VARCHAR(150),
+	start_date VARCHAR(10),
+	end_date VARCHAR(10),
+	status VARCHAR(10),
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS final_standings (
+	season INT,
+	name VARCHAR(150),
+	closed_on VARCHAR(10),
+	PRIMARY KEY(season)
+);
+
+CREATE TABLE IF NOT EXISTS final_standing (
+	season INT,
+	position INT,
+	shared BOOLEAN,
+	player_id VARCHAR(36),
+	player_name VARCHAR(150),
+	points INT,
+	last_played VARCHAR(10),
+	PRIMARY KEY(season, player_id),
+	FOREIGN KEY(season) REFERENCES final_standings(season)
+);
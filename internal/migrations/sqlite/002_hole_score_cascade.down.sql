@@ -0,0 +1,12 @@
+CREATE TABLE hole_score_plain (
+	score_id VARCHAR(36),
+	hole INT,
+	par INT,
+	stroke_index INT,
+	strokes INT,
+	PRIMARY KEY(score_id, hole)
+);
+
+INSERT INTO hole_score_plain SELECT * FROM hole_score;
+DROP TABLE hole_score;
+ALTER TABLE hole_score_plain RENAME TO hole_score;
@@ -0,0 +1,13 @@
+CREATE TABLE hole_score_cascade (
+	score_id VARCHAR(36),
+	hole INT,
+	par INT,
+	stroke_index INT,
+	strokes INT,
+	PRIMARY KEY(score_id, hole),
+	FOREIGN KEY(score_id) REFERENCES score(id) ON DELETE CASCADE
+);
+
+INSERT INTO hole_score_cascade SELECT * FROM hole_score WHERE score_id IN (SELECT id FROM score);
+DROP TABLE hole_score;
+ALTER TABLE hole_score_cascade RENAME TO hole_score;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS app_user (
+	id VARCHAR(36),
+	username VARCHAR(150) NOT NULL,
+	password_hash VARCHAR(100) NOT NULL,
+	player_id VARCHAR(36),
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY(id),
+	UNIQUE(username),
+	UNIQUE(player_id),
+	FOREIGN KEY(player_id) REFERENCES player(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS api_token (
+	id VARCHAR(36),
+	user_id VARCHAR(36) NOT NULL,
+	name VARCHAR(150) NOT NULL,
+	token_hash VARCHAR(64) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY(id),
+	UNIQUE(token_hash),
+	FOREIGN KEY(user_id) REFERENCES app_user(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE app_user ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'viewer';
+
+UPDATE app_user SET role = 'admin' WHERE id = (SELECT id FROM app_user ORDER BY created_at LIMIT 1);
@@ -0,0 +1,9 @@
+DELETE FROM hole_score WHERE score_id IN (SELECT id FROM score WHERE deleted);
+DELETE FROM score WHERE deleted;
+DELETE FROM player WHERE deleted;
+
+ALTER TABLE score DROP COLUMN deleted_at;
+ALTER TABLE score DROP COLUMN deleted;
+
+ALTER TABLE player DROP COLUMN deleted_at;
+ALTER TABLE player DROP COLUMN deleted;
@@ -0,0 +1 @@
+ALTER TABLE player ADD COLUMN archived_from INTEGER;
@@ -0,0 +1 @@
+ALTER TABLE score DROP COLUMN handicap;
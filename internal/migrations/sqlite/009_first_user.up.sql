@@ -0,0 +1,5 @@
+ALTER TABLE app_user ADD COLUMN first_user BOOLEAN CHECK (first_user);
+
+UPDATE app_user SET first_user = TRUE WHERE id = (SELECT id FROM app_user ORDER BY created_at LIMIT 1);
+
+CREATE UNIQUE INDEX app_user_first_user_key ON app_user(first_user);
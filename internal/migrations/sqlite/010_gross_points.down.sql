@@ -0,0 +1,5 @@
+UPDATE score SET points = (
+	SELECT COALESCE(SUM(MAX(2 + h.par - h.strokes + score.handicap / 18 + CASE WHEN h.stroke_index <= score.handicap % 18 THEN 1 ELSE 0 END, 0)), 0)
+	FROM hole_score h WHERE h.score_id = score.id
+)
+WHERE handicap > 0 AND EXISTS (SELECT 1 FROM hole_score h WHERE h.score_id = score.id);
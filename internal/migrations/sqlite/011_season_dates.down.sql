@@ -0,0 +1,38 @@
+CREATE TABLE season_text (
+	id INT,
+	name VARCHAR(150),
+	start_date VARCHAR(10),
+	end_date VARCHAR(10),
+	status VARCHAR(10),
+	PRIMARY KEY(id)
+);
+
+INSERT INTO season_text SELECT id, name, substr(start_date, 1, 10), substr(end_date, 1, 10), status FROM season;
+DROP TABLE season;
+ALTER TABLE season_text RENAME TO season;
+
+CREATE TABLE final_standings_text (
+	season INT,
+	name VARCHAR(150),
+	closed_on VARCHAR(10),
+	PRIMARY KEY(season)
+);
+
+CREATE TABLE final_standing_text (
+	season INT,
+	position INT,
+	shared BOOLEAN,
+	player_id VARCHAR(36),
+	player_name VARCHAR(150),
+	points INT,
+	last_played VARCHAR(10),
+	PRIMARY KEY(season, player_id),
+	FOREIGN KEY(season) REFERENCES final_standings_text(season)
+);
+
+INSERT INTO final_standings_text SELECT season, name, substr(closed_on, 1, 10) FROM final_standings;
+INSERT INTO final_standing_text SELECT season, position, shared, player_id, player_name, points, substr(last_played, 1, 10) FROM final_standing;
+DROP TABLE final_standing;
+DROP TABLE final_standings;
+ALTER TABLE final_standings_text RENAME TO final_standings;
+ALTER TABLE final_standing_text RENAME TO final_standing;
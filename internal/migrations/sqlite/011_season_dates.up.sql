@@ -0,0 +1,42 @@
+-- SQLite can not change the type of a column, so the tables are rebuilt. The dates keep their
+-- yyyy-mm-dd text which the driver reads as a time from DATE columns. The final standing is
+-- rebuilt against the new final standings before the old tables are dropped, renaming the new
+-- final standings then updates its foreign key.
+CREATE TABLE season_dates (
+	id INT,
+	name VARCHAR(150),
+	start_date DATE,
+	end_date DATE,
+	status VARCHAR(10),
+	PRIMARY KEY(id)
+);
+
+INSERT INTO season_dates SELECT id, name, start_date, end_date, status FROM season;
+DROP TABLE season;
+ALTER TABLE season_dates RENAME TO season;
+
+CREATE TABLE final_standings_dates (
+	season INT,
+	name VARCHAR(150),
+	closed_on DATE,
+	PRIMARY KEY(season)
+);
+
+CREATE TABLE final_standing_dates (
+	season INT,
+	position INT,
+	shared BOOLEAN,
+	player_id VARCHAR(36),
+	player_name VARCHAR(150),
+	points INT,
+	last_played DATE,
+	PRIMARY KEY(season, player_id),
+	FOREIGN KEY(season) REFERENCES final_standings_dates(season)
+);
+
+INSERT INTO final_standings_dates SELECT season, name, closed_on FROM final_standings;
+INSERT INTO final_standing_dates SELECT season, position, shared, player_id, player_name, points, last_played FROM final_standing;
+DROP TABLE final_standing;
+DROP TABLE final_standings;
+ALTER TABLE final_standings_dates RENAME TO final_standings;
+ALTER TABLE final_standing_dates RENAME TO final_standing;
@@ -0,0 +1,276 @@
+// Package conformance verifies that an implementation of players.Repository behaves like
+// every other backend.
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/players/model"
+
+	"github.com/google/uuid"
+)
+
+// Run runs the suite against the repositories returned by newRepository, which must return an
+// empty repository on every call.
+func Run(t *testing.T, newRepository func(t *testing.T) players.Repository) {
+	t.Helper()
+
+	ctx := context.Background()
+	at := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("empty repository has no players", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+
+		all, err := r.GetPlayers(ctx)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 0 {
+			t.Errorf("expected %d got %d", 0, len(all))
+		}
+
+		p, err := r.GetPlayerById(ctx, "unknown")
+		if err != nil || p != nil {
+			t.Errorf("expected no player and no error got %v and %v", p, err)
+		}
+	})
+	t.Run("players are ordered by name", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		create(t, r, "Bob")
+
+		all := create(t, r, "Alice")
+		if len(all) != 2 || all[0].Name != "Alice" || all[1].Name != "Bob" {
+			t.Errorf("expected Alice and Bob got %v", all)
+		}
+
+		all, _ = r.GetPlayers(ctx)
+		if len(all) != 2 || all[0].Name != "Alice" || all[1].Name != "Bob" {
+			t.Errorf("expected Alice and Bob got %v", all)
+		}
+	})
+	t.Run("created player can be fetched by id", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		all := create(t, r, "Alice")
+
+		p, err := r.GetPlayerById(ctx, all[0].Id)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if p == nil || p.Id != all[0].Id || p.Name != "Alice" {
+			t.Errorf("expected %v got %v", all[0], p)
+		}
+	})
+	t.Run("duplicate name is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		create(t, r, "Alice")
+
+		_, err := r.CreatePlayer(ctx, uuid.New().String(), "Alice")
+		if ierrors.KindOf(err) != ierrors.KindConflict {
+			t.Errorf("expected conflict error got %v", err)
+		}
+
+		all, _ := r.GetPlayers(ctx)
+		if len(all) != 1 {
+			t.Errorf("expected %d got %d", 1, len(all))
+		}
+	})
+	t.Run("update renames the player", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		all := create(t, r, "Alice")
+
+		all, err := r.UpdatePlayer(ctx, all[0].Id, "Alicia")
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 1 || all[0].Name != "Alicia" {
+			t.Errorf("expected Alicia got %v", all)
+		}
+	})
+	t.Run("update of unknown player is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+
+		_, err := r.UpdatePlayer(ctx, "unknown", "Alice")
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+	})
+	t.Run("delete removes only the player", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		create(t, r, "Alice")
+		all := create(t, r, "Bob")
+
+		all, err := r.DeletePlayer(ctx, all[0].Id, at)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 1 || all[0].Name != "Bob" {
+			t.Errorf("expected Bob got %v", all)
+		}
+
+		_, err = r.DeletePlayer(ctx, "unknown", at)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+	})
+	t.Run("archived player is only kept in earlier seasons until unarchived", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		create(t, r, "Alice")
+		all := create(t, r, "Bob")
+		id := all[1].Id
+
+		if all[1].Status != model.StatusActive {
+			t.Errorf("expected %s got %s", model.StatusActive, all[1].Status)
+		}
+
+		all, err := r.ArchivePlayer(ctx, id, 3)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 1 || all[0].Name != "Alice" {
+			t.Errorf("expected Alice got %v", all)
+		}
+
+		p, _ := r.GetPlayerById(ctx, id)
+		if p == nil || p.Status != model.StatusArchived || p.ArchivedFrom != 3 {
+			t.Errorf("expected Bob archived from season %d got %v", 3, p)
+		}
+
+		before, _ := r.GetPlayersBySeason(ctx, 2)
+		from, _ := r.GetPlayersBySeason(ctx, 3)
+
+		if len(before) != 2 || len(from) != 1 || from[0].Name != "Alice" {
+			t.Errorf("expected Alice and Bob then Alice got %v and %v", before, from)
+		}
+
+		all, err = r.ArchivePlayer(ctx, id, 0)
+		if err != nil || len(all) != 2 || all[1].Status != model.StatusActive || all[1].ArchivedFrom != 0 {
+			t.Errorf("expected active Bob and no error got %v and %v", all, err)
+		}
+
+		_, err = r.ArchivePlayer(ctx, "unknown", 3)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+	})
+	t.Run("deleted player is hidden until restored", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		id := create(t, r, "Alice")[0].Id
+		_, _ = r.DeletePlayer(ctx, id, at)
+
+		p, err := r.GetPlayerById(ctx, id)
+		if err != nil || p != nil {
+			t.Errorf("expected no player and no error got %v and %v", p, err)
+		}
+
+		_, err = r.UpdatePlayer(ctx, id, "Alicia")
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+
+		_, err = r.DeletePlayer(ctx, id, at)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+
+		all, err := r.RestorePlayer(ctx, id)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(all) != 1 || all[0].Id != id || all[0].Name != "Alice" {
+			t.Errorf("expected Alice got %v", all)
+		}
+
+		_, err = r.RestorePlayer(ctx, id)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+	})
+	t.Run("name of a deleted player can be reused but not restored twice", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		id := create(t, r, "Alice")[0].Id
+		_, _ = r.DeletePlayer(ctx, id, at)
+		create(t, r, "Alice")
+
+		_, err := r.RestorePlayer(ctx, id)
+		if ierrors.KindOf(err) != ierrors.KindConflict {
+			t.Errorf("expected conflict error got %v", err)
+		}
+	})
+	t.Run("purge removes players deleted before the given time", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRepository(t)
+		create(t, r, "Alice")
+		id := create(t, r, "Bob")[1].Id
+		_, _ = r.DeletePlayer(ctx, id, at)
+
+		purged, err := r.PurgePlayers(ctx, at.Add(-time.Hour))
+		if err != nil || purged != 0 {
+			t.Errorf("expected %d purged and no error got %d and %v", 0, purged, err)
+		}
+
+		purged, err = r.PurgePlayers(ctx, at.Add(time.Hour))
+		if err != nil || purged != 1 {
+			t.Errorf("expected %d purged and no error got %d and %v", 1, purged, err)
+		}
+
+		_, err = r.RestorePlayer(ctx, id)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+
+		all, _ := r.GetPlayers(ctx)
+		if len(all) != 1 || all[0].Name != "Alice" {
+			t.Errorf("expected Alice got %v", all)
+		}
+	})
+}
+
+func create(t *testing.T, r players.Repository, name string) []model.Player {
+	t.Helper()
+
+	created, err := r.CreatePlayer(context.Background(), uuid.New().String(), name)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	if created.Name != name || created.Status != model.StatusActive {
+		t.Fatalf("expected active player %s got %v", name, created)
+	}
+
+	all, err := r.GetPlayers(context.Background())
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	return all
+}
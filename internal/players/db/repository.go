@@ -1,23 +1,37 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/players/model"
-
-	"github.com/google/uuid"
 )
 
-const GetPlayerByIdQuery = "SELECT id, name FROM player WHERE id = $1"
-const GetCountPlayersByIdQuery = "SELECT count(*) FROM player WHERE id = $1"
-const GetCountPlayersByNameQuery = "SELECT count(*) FROM player WHERE name = $1"
-const GetPlayersQuery = "SELECT id, name from player ORDER BY name;"
+const GetPlayerByIdQuery = "SELECT id, name, COALESCE(archived_from, 0) FROM player WHERE id = $1 AND NOT deleted"
+const GetCountPlayersByIdQuery = "SELECT count(*) FROM player WHERE id = $1 AND NOT deleted"
+const GetCountPlayersByNameQuery = "SELECT count(*) FROM player WHERE name = $1 AND NOT deleted"
+const GetDeletedPlayerQuery = "SELECT id, name, COALESCE(archived_from, 0) FROM player WHERE id = $1 AND deleted"
+const GetPlayersQuery = "SELECT id, name, COALESCE(archived_from, 0) from player WHERE NOT deleted AND archived_from IS NULL ORDER BY name;"
+
+// GetPlayersBySeasonQuery archived players are kept in the seasons before the one they left in.
+const GetPlayersBySeasonQuery = `
+	SELECT id, name, COALESCE(archived_from, 0) from player
+	WHERE NOT deleted AND (archived_from IS NULL OR archived_from > $1)
+	ORDER BY name;
+`
 const InsertPlayerQuery = "INSERT INTO player (id, name) VALUES ($1, $2);"
 const UpdatePlayerQuery = "UPDATE player SET name = $2 WHERE id = $1;"
-const DeletePlayerQuery = "DELETE FROM player WHERE id = $1;"
+const DeletePlayerQuery = "UPDATE player SET deleted = TRUE, deleted_at = $2 WHERE id = $1;"
+const ArchivePlayerQuery = "UPDATE player SET archived_from = $2 WHERE id = $1;"
+const RestorePlayerQuery = "UPDATE player SET deleted = FALSE, deleted_at = NULL WHERE id = $1;"
+
+// PurgePlayersQuery the scores of the players are removed by the cascade of the foreign key.
+const PurgePlayersQuery = "DELETE FROM player WHERE deleted AND deleted_at < $1;"
 
+// PostgresRepository the queries are kept portable so the repository also serves the SQLite mode.
 type PostgresRepository struct {
 	db *sql.DB
 }
@@ -26,159 +40,219 @@ func NewRepository(db *sql.DB) *PostgresRepository {
 	return &PostgresRepository{db: db}
 }
 
-func (r *PostgresRepository) GetPlayerById(id string) (*model.Player, error) {
-	stmt, err := r.db.Prepare(GetPlayerByIdQuery)
-	if err != nil {
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error preparing statement %v", err),
-		}
-	}
+func (r *PostgresRepository) GetPlayerById(ctx context.Context, id string) (*model.Player, error) {
+	return r.queryPlayer(ctx, GetPlayerByIdQuery, id)
+}
 
+// GetDeletedPlayer returns the deleted player which has not been purged yet or nil if there is none.
+func (r *PostgresRepository) GetDeletedPlayer(ctx context.Context, id string) (*model.Player, error) {
+	return r.queryPlayer(ctx, GetDeletedPlayerQuery, id)
+}
+
+func (r *PostgresRepository) queryPlayer(ctx context.Context, query, id string) (*model.Player, error) {
 	var playerId string
 
 	var playerName string
 
-	err = stmt.QueryRow(id).Scan(&playerId, &playerName)
+	var archivedFrom int
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&playerId, &playerName, &archivedFrom)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error scanning result %v", err),
-		}
+		return nil, ierrors.Database("error scanning result", err)
 	}
 
-	return &model.Player{
-		Id:   playerId,
-		Name: playerName,
-	}, nil
+	p := toPlayer(playerId, playerName, archivedFrom)
+
+	return &p, nil
 }
 
-func (r *PostgresRepository) GetPlayers() ([]model.Player, error) {
-	rows, err := r.db.Query(GetPlayersQuery)
+// GetPlayers returns the players which are not archived ordered by name.
+func (r *PostgresRepository) GetPlayers(ctx context.Context) ([]model.Player, error) {
+	return r.queryPlayers(ctx, GetPlayersQuery)
+}
+
+// GetPlayersBySeason returns the players taking part in the season ordered by name.
+func (r *PostgresRepository) GetPlayersBySeason(ctx context.Context, season int) ([]model.Player, error) {
+	return r.queryPlayers(ctx, GetPlayersBySeasonQuery, season)
+}
+
+// CreatePlayer returns the created player.
+func (r *PostgresRepository) CreatePlayer(ctx context.Context, id, name string) (*model.Player, error) {
+	count, err := r.countPlayersByQuery(ctx, GetCountPlayersByNameQuery, name)
 	if err != nil {
-		return nil, ierrors.DbError{Message: fmt.Sprintf("error fetching players %v", err)}
+		return nil, err
 	}
 
-	players := make([]model.Player, 0)
+	if count > 0 {
+		return nil, ierrors.Conflict(fmt.Sprintf("player with name %s already exists.", name))
+	}
 
-	for rows.Next() {
-		var id string
+	_, err = r.db.ExecContext(ctx, InsertPlayerQuery, id, name)
+	if err != nil {
+		return nil, ierrors.Database("error executing statement insert player", err)
+	}
 
-		var name string
+	p := toPlayer(id, name, 0)
 
-		err = rows.Scan(&id, &name)
-		if err != nil {
-			return nil, ierrors.DbError{Message: fmt.Sprintf("error scanning rows %v", err)}
-		}
+	return &p, nil
+}
 
-		players = append(players, model.Player{
-			Id:   id,
-			Name: name,
-		})
+func (r *PostgresRepository) UpdatePlayer(ctx context.Context, id, name string) ([]model.Player, error) {
+	count, err := r.countPlayersByQuery(ctx, GetCountPlayersByIdQuery, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return players, nil
+	if count == 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
+	}
+
+	_, err = r.db.ExecContext(ctx, UpdatePlayerQuery, id, name)
+	if err != nil {
+		return nil, ierrors.Database("error executing update player query", err)
+	}
+
+	return r.GetPlayers(ctx)
 }
 
-func (r *PostgresRepository) CreatePlayer(name string) ([]model.Player, error) {
-	count, err := r.countPlayersByQuery(GetCountPlayersByNameQuery, name)
+func (r *PostgresRepository) DeletePlayer(ctx context.Context, id string, at time.Time) ([]model.Player, error) {
+	count, err := r.countPlayersByQuery(ctx, GetCountPlayersByIdQuery, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if count > 0 {
-		return nil, ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    fmt.Sprintf("player with name %s already exists.", name),
-			InnerError: "",
-		}
+	if count == 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
 	}
 
-	stmt, err := r.db.Prepare(InsertPlayerQuery)
+	_, err = r.db.ExecContext(ctx, DeletePlayerQuery, id, at)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error preparing insert player query %v", err),
-		}
+		return nil, ierrors.Database("error executing delete player query", err)
 	}
 
-	id := uuid.New().String()
+	return r.GetPlayers(ctx)
+}
 
-	_, err = stmt.Exec(id, name)
+// ArchivePlayer archives the player from the season, zero makes the player active again.
+func (r *PostgresRepository) ArchivePlayer(ctx context.Context, id string, season int) ([]model.Player, error) {
+	count, err := r.countPlayersByQuery(ctx, GetCountPlayersByIdQuery, id)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error executing statement insert player %v", err),
-		}
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
+	}
+
+	var archivedFrom any
+	if season > 0 {
+		archivedFrom = season
 	}
 
-	return r.GetPlayers()
+	_, err = r.db.ExecContext(ctx, ArchivePlayerQuery, id, archivedFrom)
+	if err != nil {
+		return nil, ierrors.Database("error executing archive player query", err)
+	}
+
+	return r.GetPlayers(ctx)
 }
 
-func (r *PostgresRepository) UpdatePlayer(id, name string) ([]model.Player, error) {
-	count, err := r.countPlayersByQuery(GetCountPlayersByIdQuery, id)
+// RestorePlayer brings back a deleted player together with its scores.
+func (r *PostgresRepository) RestorePlayer(ctx context.Context, id string) ([]model.Player, error) {
+	deleted, err := r.GetDeletedPlayer(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if count == 0 {
-		return nil, ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    fmt.Sprintf("player with id %s does not exist", id),
-			InnerError: "",
-		}
+	if deleted == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("deleted player with id %s does not exist", id))
 	}
 
-	stmt, err := r.db.Prepare(UpdatePlayerQuery)
+	count, err := r.countPlayersByQuery(ctx, GetCountPlayersByNameQuery, deleted.Name)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error preparing udpate player query %v", err),
-		}
+		return nil, err
+	}
+
+	if count > 0 {
+		return nil, ierrors.Conflict(fmt.Sprintf("player with name %s already exists.", deleted.Name))
 	}
 
-	_, err = stmt.Exec(id, name)
+	_, err = r.db.ExecContext(ctx, RestorePlayerQuery, id)
 	if err != nil {
-		return nil, ierrors.DbError{Message: fmt.Sprintf("error executing update player query %v", err)}
+		return nil, ierrors.Database("error executing restore player query", err)
 	}
 
-	return r.GetPlayers()
+	return r.GetPlayers(ctx)
 }
 
-func (r *PostgresRepository) DeletePlayer(id string) ([]model.Player, error) {
-	stmt, err := r.db.Prepare(DeletePlayerQuery)
+// PurgePlayers removes the players deleted before the given time for good and returns how many
+// were removed.
+func (r *PostgresRepository) PurgePlayers(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, PurgePlayersQuery, before.UTC())
 	if err != nil {
-		return nil, ierrors.DbError{Message: fmt.Sprintf("error preparing delete player query %v", err)}
+		return 0, ierrors.Database("error executing purge players query", err)
 	}
 
-	_, err = stmt.Exec(id)
+	purged, err := result.RowsAffected()
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error executing delete player query %v", err),
-		}
+		return 0, ierrors.Database("error reading purged players", err)
 	}
 
-	return r.GetPlayers()
+	return int(purged), nil
 }
 
-func (r *PostgresRepository) countPlayersByQuery(query string, param string) (int, error) {
-	stmt, err := r.db.Prepare(query)
+func (r *PostgresRepository) queryPlayers(ctx context.Context, query string, args ...any) ([]model.Player, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return 0, ierrors.DbError{Message: fmt.Sprintf("error preparing player count query %v", err)}
+		return nil, ierrors.Database("error fetching players", err)
 	}
 
-	rows, err := stmt.Query(param)
-	if err != nil {
-		return 0, ierrors.DbError{Message: fmt.Sprintf("error running player count query %v", err)}
-	}
+	defer rows.Close()
+
+	players := make([]model.Player, 0)
 
-	var count int
 	for rows.Next() {
-		err = rows.Scan(&count)
+		var id string
+
+		var name string
+
+		var archivedFrom int
+
+		err = rows.Scan(&id, &name, &archivedFrom)
 		if err != nil {
-			return 0, ierrors.DbError{Message: fmt.Sprintf("error scanning result of player count query %v", err)}
+			return nil, ierrors.Database("error scanning rows", err)
 		}
+
+		players = append(players, toPlayer(id, name, archivedFrom))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading players", err)
+	}
+
+	return players, nil
+}
+
+func (r *PostgresRepository) countPlayersByQuery(ctx context.Context, query string, param string) (int, error) {
+	var count int
+
+	err := r.db.QueryRowContext(ctx, query, param).Scan(&count)
+	if err != nil {
+		return 0, ierrors.Database("error running player count query", err)
 	}
 
 	return count, nil
 }
+
+func toPlayer(id, name string, archivedFrom int) model.Player {
+	if archivedFrom == 0 {
+		return model.Player{Id: id, Name: name, Status: model.StatusActive}
+	}
+
+	return model.Player{Id: id, Name: name, Status: model.StatusArchived, ArchivedFrom: archivedFrom}
+}
@@ -0,0 +1,59 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"tour-le-shit-go/internal/dbtest"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/players/conformance"
+	"tour-le-shit-go/internal/players/db"
+)
+
+func TestSqliteConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) players.Repository {
+		t.Helper()
+
+		return db.NewRepository(dbtest.Sqlite(t))
+	})
+}
+
+func TestPostgresConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) players.Repository {
+		t.Helper()
+
+		return db.NewRepository(dbtest.Postgres(t))
+	})
+}
+
+func TestSqliteCancelledContext(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := db.NewRepository(dbtest.Sqlite(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// act
+	_, err := r.GetPlayers(ctx)
+
+	// assert
+	if ierrors.KindOf(err) != ierrors.KindUnavailable {
+		t.Errorf("expected unavailable error got %v", err)
+	}
+
+	_, err = r.CreatePlayer(ctx, "1", "Alice")
+	if err == nil {
+		t.Errorf("expected error got none")
+	}
+
+	all, _ := r.GetPlayers(context.Background())
+	if len(all) != 0 {
+		t.Errorf("expected %d got %d", 0, len(all))
+	}
+}
@@ -1,97 +1,302 @@
 package mock
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/players/model"
-
-	"github.com/google/uuid"
+	"tour-le-shit-go/internal/utils"
 )
 
+// MockedRepository keeps the players in memory and is safe for concurrent use. Like the database
+// deleted players are kept until they are purged.
 type MockedRepository struct {
-	members []model.Player
+	mu      sync.RWMutex
+	members []member
+	onPurge []func(ids []string)
+}
+
+// member a stored player, DeletedAt is set while the player is deleted. The JSON of a member
+// is the JSON of its player with DeletedAt added, which keeps files saved before players
+// could be deleted loadable.
+type member struct {
+	model.Player
+	DeletedAt *time.Time `json:",omitempty"`
 }
 
 func NewRepository(members []model.Player) *MockedRepository {
-	return &MockedRepository{members: members}
+	stored := make([]member, 0, len(members))
+	for _, m := range members {
+		stored = append(stored, member{Player: withStatus(m)})
+	}
+
+	return &MockedRepository{members: stored}
 }
 
-func (r *MockedRepository) GetPlayerById(id string) (*model.Player, error) {
-	for _, m := range r.members {
-		if m.Id == id {
-			return &model.Player{
-				Id:   m.Id,
-				Name: m.Name,
-			}, nil
+func (r *MockedRepository) GetPlayerById(_ context.Context, id string) (*model.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.index(id, false)
+	if i < 0 {
+		return nil, nil
+	}
+
+	p := r.members[i].Player
+
+	return &p, nil
+}
+
+// GetPlayers returns the players which are not archived ordered by name.
+func (r *MockedRepository) GetPlayers(_ context.Context) ([]model.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sorted(), nil
+}
+
+// GetPlayersBySeason returns the players taking part in the season ordered by name.
+func (r *MockedRepository) GetPlayersBySeason(_ context.Context, season int) ([]model.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedBy(func(p model.Player) bool { return p.ActiveIn(season) }), nil
+}
+
+// CreatePlayer returns the created player.
+func (r *MockedRepository) CreatePlayer(_ context.Context, id, name string) (*model.Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(name) {
+		return nil, ierrors.Conflict(fmt.Sprintf("name %s already exists.", name))
+	}
+
+	p := model.Player{
+		Id:     id,
+		Name:   name,
+		Status: model.StatusActive,
+	}
+	r.members = append(r.members, member{Player: p})
+
+	return &p, nil
+}
+
+func (r *MockedRepository) UpdatePlayer(_ context.Context, id, name string) ([]model.Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id, false)
+	if i < 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
+	}
+
+	r.members[i].Name = name
+
+	return r.sorted(), nil
+}
+
+func (r *MockedRepository) DeletePlayer(_ context.Context, id string, at time.Time) ([]model.Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id, false)
+	if i < 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
+	}
+
+	r.members[i].DeletedAt = &at
+
+	return r.sorted(), nil
+}
+
+// ArchivePlayer archives the player from the season, zero makes the player active again.
+func (r *MockedRepository) ArchivePlayer(_ context.Context, id string, season int) ([]model.Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id, false)
+	if i < 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
+	}
+
+	r.members[i].Status = model.StatusArchived
+	r.members[i].ArchivedFrom = season
+
+	if season == 0 {
+		r.members[i].Status = model.StatusActive
+	}
+
+	return r.sorted(), nil
+}
+
+// GetDeletedPlayer returns the deleted player which has not been purged yet or nil if there is none.
+func (r *MockedRepository) GetDeletedPlayer(_ context.Context, id string) (*model.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.index(id, true)
+	if i < 0 {
+		return nil, nil
+	}
+
+	p := r.members[i].Player
+
+	return &p, nil
+}
+
+// RestorePlayer brings back a deleted player together with its scores.
+func (r *MockedRepository) RestorePlayer(_ context.Context, id string) ([]model.Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id, true)
+	if i < 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("deleted player with id %s does not exist", id))
+	}
+
+	if r.nameTaken(r.members[i].Name) {
+		return nil, ierrors.Conflict(fmt.Sprintf("name %s already exists.", r.members[i].Name))
+	}
+
+	r.members[i].DeletedAt = nil
+
+	return r.sorted(), nil
+}
+
+// PurgePlayers removes the players deleted before the given time for good and returns how many
+// were removed. The functions registered with OnPurge are called with the removed ids once the
+// lock is released, since they may read the players.
+func (r *MockedRepository) PurgePlayers(_ context.Context, before time.Time) (int, error) {
+	ids, hooks := r.purge(before)
+
+	if len(ids) > 0 {
+		for _, f := range hooks {
+			f(ids)
 		}
 	}
 
-	return nil, nil
+	return len(ids), nil
 }
 
-func (r *MockedRepository) GetPlayers() ([]model.Player, error) {
-	return r.members, nil
+// OnPurge registers f to be called with the ids of the players removed by PurgePlayers, the way
+// the database removes the rounds of a player along with the player.
+func (r *MockedRepository) OnPurge(f func(ids []string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onPurge = append(r.onPurge, f)
 }
 
-func (r *MockedRepository) CreatePlayer(name string) ([]model.Player, error) {
+// purge removes the players deleted before the given time and returns their ids together with
+// the functions to call with them.
+func (r *MockedRepository) purge(before time.Time) ([]string, []func(ids []string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]member, 0, len(r.members))
+	ids := make([]string, 0)
+
 	for _, m := range r.members {
-		if name == m.Name {
-			return nil, ierrors.HttpError{
-				Code:       ierrors.BadRequestStatusCode,
-				Message:    fmt.Sprintf("name %s already exists.", name),
-				InnerError: "",
-			}
+		if m.DeletedAt == nil || !m.DeletedAt.Before(before) {
+			kept = append(kept, m)
+
+			continue
 		}
+
+		ids = append(ids, m.Id)
 	}
 
-	r.members = append(r.members, model.Player{
-		Id:   uuid.New().String(),
-		Name: name,
-	})
+	r.members = kept
 
-	return r.members, nil
+	return ids, r.onPurge
 }
 
-func (r *MockedRepository) UpdatePlayer(id, name string) ([]model.Player, error) {
-	indexToUpdate := -1
+// Load replaces the players with the ones saved to path, nothing changes if the file is missing.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
+	var members []member
+	if err := utils.ReadJson(path, &members); err != nil {
+		return err
+	}
+
+	for i := range members {
+		members[i].Player = withStatus(members[i].Player)
+	}
+
+	if members != nil {
+		r.members = members
+	}
+
+	return nil
+}
+
+// Save writes the players, deleted ones included, to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, r.members)
+}
+
+// index returns the position of the player which is deleted or not, -1 when there is none. The
+// caller must hold the lock.
+func (r *MockedRepository) index(id string, deleted bool) int {
 	for i, m := range r.members {
-		if id == m.Id {
-			indexToUpdate = i
+		if m.Id == id && (m.DeletedAt != nil) == deleted {
+			return i
 		}
 	}
 
-	if indexToUpdate < 0 {
-		return nil, ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    fmt.Sprintf("player with id %s does not exist", id),
-			InnerError: "",
+	return -1
+}
+
+// nameTaken tells if a player which is not deleted has the name, the caller must hold the lock.
+func (r *MockedRepository) nameTaken(name string) bool {
+	for _, m := range r.members {
+		if m.DeletedAt == nil && m.Name == name {
+			return true
 		}
 	}
 
-	r.members[indexToUpdate] = model.Player{
-		Id:   id,
-		Name: name,
-	}
+	return false
+}
 
-	return r.members, nil
+// sorted returns the players which are neither deleted nor archived ordered by name, the caller
+// must hold the lock.
+func (r *MockedRepository) sorted() []model.Player {
+	return r.sortedBy(func(p model.Player) bool { return p.Status != model.StatusArchived })
 }
 
-func (r *MockedRepository) DeletePlayer(id string) ([]model.Player, error) {
-	updatedMembers := make([]model.Player, 0)
+// sortedBy returns the players which are not deleted and match ordered by name, the caller must
+// hold the lock.
+func (r *MockedRepository) sortedBy(match func(p model.Player) bool) []model.Player {
+	result := make([]model.Player, 0, len(r.members))
 
 	for _, m := range r.members {
-		if m.Id == id {
-			continue
+		if m.DeletedAt == nil && match(m.Player) {
+			result = append(result, m.Player)
 		}
-
-		updatedMembers = append(updatedMembers, model.Player{
-			Id:   m.Id,
-			Name: m.Name,
-		})
 	}
 
-	r.members = updatedMembers
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// withStatus makes players without a status, like the ones saved before players could be
+// archived, active.
+func withStatus(p model.Player) model.Player {
+	if p.Status == "" {
+		p.Status = model.StatusActive
+	}
 
-	return r.members, nil
+	return p
 }
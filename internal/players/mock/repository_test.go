@@ -0,0 +1,138 @@
+package mock_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/players/conformance"
+	"tour-le-shit-go/internal/players/mock"
+	"tour-le-shit-go/internal/players/model"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) players.Repository {
+		t.Helper()
+
+		return mock.NewRepository([]model.Player{})
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "members.json")
+
+	t.Run("saved players are loaded by a new repository", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		saved := mock.NewRepository([]model.Player{})
+		_, _ = saved.CreatePlayer(context.Background(), "1", "Bob")
+		_, _ = saved.CreatePlayer(context.Background(), "2", "Alice")
+
+		err := saved.Save(path)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		loaded := mock.NewRepository([]model.Player{})
+
+		// act
+		err = loaded.Load(path)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		want, _ := saved.GetPlayers(context.Background())
+		got, _ := loaded.GetPlayers(context.Background())
+
+		if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("expected %v got %v", want, got)
+		}
+	})
+	t.Run("deleted players stay deleted and can be restored after loading", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		deletedPath := filepath.Join(t.TempDir(), "members.json")
+		saved := mock.NewRepository([]model.Player{{Id: "1", Name: "Alice"}, {Id: "2", Name: "Bob"}})
+		_, _ = saved.DeletePlayer(context.Background(), "1", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		err := saved.Save(deletedPath)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		loaded := mock.NewRepository([]model.Player{})
+
+		// act
+		err = loaded.Load(deletedPath)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, _ := loaded.GetPlayers(context.Background())
+		if len(got) != 1 || got[0].Name != "Bob" {
+			t.Errorf("expected Bob got %v", got)
+		}
+
+		got, err = loaded.RestorePlayer(context.Background(), "1")
+		if err != nil || len(got) != 2 {
+			t.Errorf("expected Alice and Bob got %v and %v", got, err)
+		}
+	})
+	t.Run("players saved before archiving was added are active", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		legacyPath := filepath.Join(t.TempDir(), "members.json")
+
+		err := os.WriteFile(legacyPath, []byte(`[{"Id": "1", "Name": "Alice"}]`), 0o600)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		r := mock.NewRepository([]model.Player{})
+
+		// act
+		err = r.Load(legacyPath)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, _ := r.GetPlayers(context.Background())
+		if len(got) != 1 || got[0].Status != model.StatusActive {
+			t.Errorf("expected active Alice got %v", got)
+		}
+	})
+	t.Run("missing file keeps the players", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := mock.NewRepository([]model.Player{{Id: "1", Name: "Alice"}})
+
+		// act
+		err := r.Load(filepath.Join(t.TempDir(), "missing.json"))
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, _ := r.GetPlayers(context.Background())
+		if len(got) != 1 {
+			t.Errorf("expected %d got %d", 1, len(got))
+		}
+	})
+}
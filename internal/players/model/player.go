@@ -1,6 +1,19 @@
 package model
 
+const StatusActive = "active"
+const StatusArchived = "archived"
+
+// Player a member of the tour. An archived player has left the tour, ArchivedFrom is the first
+// season the player no longer takes part in.
 type Player struct {
-	Id   string
-	Name string
+	Id           string
+	Name         string
+	Status       string
+	ArchivedFrom int
+}
+
+// ActiveIn tells if the player takes part in the season, archived players keep their place on
+// the scoreboards of the seasons before they left.
+func (p Player) ActiveIn(season int) bool {
+	return p.Status != StatusArchived || season < p.ArchivedFrom
 }
@@ -1,35 +1,66 @@
 package players
 
 import (
+	"context"
 	"fmt"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	auditModel "tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/players/model"
+	"tour-le-shit-go/internal/policy"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/google/uuid"
 )
 
 type Repository interface {
-	GetPlayerById(id string) (*model.Player, error)
-	GetPlayers() ([]model.Player, error)
-	CreatePlayer(name string) ([]model.Player, error)
-	UpdatePlayer(id, name string) ([]model.Player, error)
-	DeletePlayer(id string) ([]model.Player, error)
+	GetPlayerById(ctx context.Context, id string) (*model.Player, error)
+	GetDeletedPlayer(ctx context.Context, id string) (*model.Player, error)
+	GetPlayers(ctx context.Context) ([]model.Player, error)
+	GetPlayersBySeason(ctx context.Context, season int) ([]model.Player, error)
+	CreatePlayer(ctx context.Context, id, name string) (*model.Player, error)
+	UpdatePlayer(ctx context.Context, id, name string) ([]model.Player, error)
+	DeletePlayer(ctx context.Context, id string, at time.Time) ([]model.Player, error)
+	ArchivePlayer(ctx context.Context, id string, season int) ([]model.Player, error)
+	RestorePlayer(ctx context.Context, id string) ([]model.Player, error)
+	PurgePlayers(ctx context.Context, before time.Time) (int, error)
 }
 
 type Service interface {
-	GetMembers() ([]model.Player, error)
-	CreateMember(name string) ([]model.Player, error)
-	UpdateMember(id, name string) ([]model.Player, error)
-	DeleteMember(id string) ([]model.Player, error)
+	GetMember(ctx context.Context, id string) (*model.Player, error)
+	GetMembers(ctx context.Context) ([]model.Player, error)
+	CreateMember(ctx context.Context, name string) (*model.Player, error)
+	UpdateMember(ctx context.Context, id, name string) ([]model.Player, error)
+	DeleteMember(ctx context.Context, id string) ([]model.Player, error)
+	ArchiveMember(ctx context.Context, id string, season int) ([]model.Player, error)
+	UnarchiveMember(ctx context.Context, id string) ([]model.Player, error)
+	RestoreMember(ctx context.Context, id string) ([]model.Player, error)
+	PurgeMembers(ctx context.Context, before time.Time) (int, error)
 }
 
 type service struct {
-	r Repository
+	r     Repository
+	audit audit.Service
+	clock utils.Clock
 }
 
-func NewService(r Repository) Service {
-	return &service{r: r}
+// NewService returns a service recording every change of a member in the audit log.
+func NewService(r Repository, a audit.Service, clock utils.Clock) Service {
+	return &service{r: r, audit: a, clock: clock}
 }
 
-func (s *service) GetMembers() ([]model.Player, error) {
-	p, err := s.r.GetPlayers()
+func (s *service) GetMember(ctx context.Context, id string) (*model.Player, error) {
+	p, err := s.r.GetPlayerById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching player with id %s from repository %w", id, err)
+	}
+
+	return p, nil
+}
+
+func (s *service) GetMembers(ctx context.Context) ([]model.Player, error) {
+	p, err := s.r.GetPlayers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching players from repository %w", err)
 	}
@@ -37,8 +68,22 @@ func (s *service) GetMembers() ([]model.Player, error) {
 	return p, nil
 }
 
-func (s *service) CreateMember(name string) ([]model.Player, error) {
-	p, err := s.r.CreatePlayer(name)
+// CreateMember returns the created member.
+func (s *service) CreateMember(ctx context.Context, name string) (*model.Player, error) {
+	err := policy.Admin(ctx, "manage members")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	created := model.Player{Id: uuid.New().String(), Name: name, Status: model.StatusActive}
+
+	var p *model.Player
+
+	err = s.audit.Record(ctx, auditModel.EntityPlayer, created.Id, auditModel.ActionCreate, nil, created, func() (err error) {
+		p, err = s.r.CreatePlayer(ctx, created.Id, name)
+
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error creating player from repository %w", err)
 	}
@@ -46,8 +91,27 @@ func (s *service) CreateMember(name string) ([]model.Player, error) {
 	return p, nil
 }
 
-func (s *service) UpdateMember(id, name string) ([]model.Player, error) {
-	p, err := s.r.UpdatePlayer(id, name)
+func (s *service) UpdateMember(ctx context.Context, id, name string) ([]model.Player, error) {
+	err := policy.Admin(ctx, "manage members")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	before, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *before
+	after.Name = name
+
+	var p []model.Player
+
+	err = s.audit.Record(ctx, auditModel.EntityPlayer, id, auditModel.ActionUpdate, *before, after, func() (err error) {
+		p, err = s.r.UpdatePlayer(ctx, id, name)
+
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error updating player from repository %w", err)
 	}
@@ -55,11 +119,136 @@ func (s *service) UpdateMember(id, name string) ([]model.Player, error) {
 	return p, nil
 }
 
-func (s *service) DeleteMember(id string) ([]model.Player, error) {
-	p, err := s.r.DeletePlayer(id)
+func (s *service) DeleteMember(ctx context.Context, id string) ([]model.Player, error) {
+	err := policy.Admin(ctx, "manage members")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	before, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var p []model.Player
+
+	err = s.audit.Record(ctx, auditModel.EntityPlayer, id, auditModel.ActionDelete, *before, nil, func() (err error) {
+		p, err = s.r.DeletePlayer(ctx, id, s.clock.Now().UTC())
+
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error deleting player from repository %w", err)
 	}
 
 	return p, nil
 }
+
+// ArchiveMember archives a member who left the tour before the season. The member is kept on
+// the scoreboards of the earlier seasons.
+func (s *service) ArchiveMember(ctx context.Context, id string, season int) ([]model.Player, error) {
+	if season < 1 {
+		return nil, ierrors.Invalid("season", "season must be at least 1")
+	}
+
+	return s.archive(ctx, id, season)
+}
+
+// UnarchiveMember makes an archived member active again.
+func (s *service) UnarchiveMember(ctx context.Context, id string) ([]model.Player, error) {
+	return s.archive(ctx, id, 0)
+}
+
+func (s *service) archive(ctx context.Context, id string, season int) ([]model.Player, error) {
+	err := policy.Admin(ctx, "manage members")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	before, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	action := auditModel.ActionArchive
+	after := *before
+	after.Status = model.StatusArchived
+	after.ArchivedFrom = season
+
+	if season == 0 {
+		action = auditModel.ActionUnarchive
+		after.Status = model.StatusActive
+	}
+
+	var p []model.Player
+
+	err = s.audit.Record(ctx, auditModel.EntityPlayer, id, action, *before, after, func() (err error) {
+		p, err = s.r.ArchivePlayer(ctx, id, season)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error archiving player from repository %w", err)
+	}
+
+	return p, nil
+}
+
+// RestoreMember brings back a deleted member together with its rounds.
+func (s *service) RestoreMember(ctx context.Context, id string) ([]model.Player, error) {
+	err := policy.Admin(ctx, "manage members")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	deleted, err := s.r.GetDeletedPlayer(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching deleted player with id %s from repository %w", id, err)
+	}
+
+	if deleted == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("deleted player with id %s does not exist", id))
+	}
+
+	var p []model.Player
+
+	err = s.audit.Record(ctx, auditModel.EntityPlayer, id, auditModel.ActionRestore, nil, *deleted, func() (err error) {
+		p, err = s.r.RestorePlayer(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error restoring player from repository %w", err)
+	}
+
+	return p, nil
+}
+
+// PurgeMembers removes the members deleted before the given time, and their rounds, for good.
+func (s *service) PurgeMembers(ctx context.Context, before time.Time) (int, error) {
+	err := policy.Admin(ctx, "purge members")
+	if err != nil {
+		return 0, fmt.Errorf("error authorising %w", err)
+	}
+
+	purged, err := s.r.PurgePlayers(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("error purging players from repository %w", err)
+	}
+
+	return purged, nil
+}
+
+// existing returns the member which is not deleted or an error if there is none.
+func (s *service) existing(ctx context.Context, id string) (*model.Player, error) {
+	p, err := s.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("player with id %s does not exist", id))
+	}
+
+	return p, nil
+}
@@ -0,0 +1,69 @@
+// Package policy decides what the user of a request may do. Admins manage members, seasons,
+// courses, rules and users, scorekeepers enter the rounds of anyone, players enter their own
+// rounds and viewers only read, which every role may do.
+//
+// A context without a user is trusted, which is how the server runs without authentication.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/ierrors"
+)
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user model.User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the authenticated user of ctx, nil when the request is anonymous.
+func UserFrom(ctx context.Context) *model.User {
+	user, ok := ctx.Value(userKey{}).(model.User)
+	if !ok {
+		return nil
+	}
+
+	return &user
+}
+
+// IsRole tells if role is one of the roles of a user.
+func IsRole(role string) bool {
+	switch role {
+	case model.RoleAdmin, model.RoleScorekeeper, model.RolePlayer, model.RoleViewer:
+		return true
+	}
+
+	return false
+}
+
+// Admin allows admins to perform the action.
+func Admin(ctx context.Context, action string) error {
+	user := UserFrom(ctx)
+	if user == nil || user.Role == model.RoleAdmin {
+		return nil
+	}
+
+	return ierrors.Forbidden(fmt.Sprintf("only admins can %s", action))
+}
+
+// Round allows admins and scorekeepers to enter and edit the rounds of any player, and players
+// to enter and edit their own rounds.
+func Round(ctx context.Context, playerId string) error {
+	user := UserFrom(ctx)
+	if user == nil || user.Role == model.RoleAdmin || user.Role == model.RoleScorekeeper {
+		return nil
+	}
+
+	if user.Role == model.RolePlayer && user.PlayerId != "" && user.PlayerId == playerId {
+		return nil
+	}
+
+	if user.Role == model.RolePlayer {
+		return ierrors.Forbidden("players can only enter and edit their own rounds")
+	}
+
+	return ierrors.Forbidden("only scorekeepers and players can enter and edit rounds")
+}
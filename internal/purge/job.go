@@ -0,0 +1,72 @@
+// Package purge removes deleted members and rounds for good once they have been deleted for
+// longer than the retention period.
+package purge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/score"
+	"tour-le-shit-go/internal/utils"
+)
+
+// Interval how often Schedule purges.
+const Interval = time.Hour
+
+// Result how many deleted items a run removed.
+type Result struct {
+	Players int
+	Scores  int
+}
+
+type Job struct {
+	players   players.Service
+	scores    score.Service
+	retention time.Duration
+	clock     utils.Clock
+}
+
+// NewJob returns a job purging what was deleted more than retention ago.
+func NewJob(p players.Service, s score.Service, retention time.Duration, clock utils.Clock) *Job {
+	return &Job{players: p, scores: s, retention: retention, clock: clock}
+}
+
+// Run purges once. Rounds go first since purging a player also removes its rounds.
+func (j *Job) Run(ctx context.Context) (Result, error) {
+	before := j.clock.Now().UTC().Add(-j.retention)
+
+	scores, err := j.scores.PurgeScores(ctx, before)
+	if err != nil {
+		return Result{}, fmt.Errorf("error purging scores %w", err)
+	}
+
+	purged, err := j.players.PurgeMembers(ctx, before)
+	if err != nil {
+		return Result{Scores: scores}, fmt.Errorf("error purging members %w", err)
+	}
+
+	return Result{Players: purged, Scores: scores}, nil
+}
+
+// Schedule runs the job right away and then every interval until ctx is done.
+func (j *Job) Schedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := j.Run(ctx)
+		if err != nil {
+			log.Printf("could not purge deleted items: %v", err)
+		} else if result.Players > 0 || result.Scores > 0 {
+			log.Printf("purged %d members and %d rounds", result.Players, result.Scores)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	"tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/utils"
+)
+
+// Entry a change of a member or a round. Before and After are the entity before and after the
+// change, Actor is the username of the user who made it.
+type Entry struct {
+	Id       string          `json:"id"`
+	Entity   string          `json:"entity"`
+	EntityId string          `json:"entityId"`
+	Action   string          `json:"action"`
+	Actor    string          `json:"actor"`
+	At       string          `json:"at"`
+	Before   json.RawMessage `json:"before,omitempty"`
+	After    json.RawMessage `json:"after,omitempty"`
+}
+
+type Route struct {
+	s audit.Service
+}
+
+func NewAuditRoute(s audit.Service) Route {
+	return Route{s: s}
+}
+
+// GetAuditRouteHandler returns the audit log, optionally filtered by entity, actor and the days
+// from and to, both inclusive and in UTC.
+func (r *Route) GetAuditRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	filter, err := readFilter(req)
+	if err != nil {
+		return err
+	}
+
+	entries, err := r.s.GetEntries(req.Context(), filter)
+	if err != nil {
+		return fmt.Errorf("error fetching audit entries %w", err)
+	}
+
+	result := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, Entry{
+			Id:       e.Id,
+			Entity:   e.Entity,
+			EntityId: e.EntityId,
+			Action:   e.Action,
+			Actor:    e.Actor,
+			At:       e.At.UTC().Format(time.RFC3339),
+			Before:   rawJson(e.Before),
+			After:    rawJson(e.After),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func readFilter(req *http.Request) (model.Filter, error) {
+	query := req.URL.Query()
+	filter := model.Filter{
+		Entity: query.Get("entity"),
+		Actor:  query.Get("actor"),
+	}
+
+	switch filter.Entity {
+	case "", model.EntityPlayer, model.EntityScore:
+	default:
+		return model.Filter{}, ierrors.Invalid("entity", fmt.Sprintf("invalid entity query param, expected player or score got %s", filter.Entity))
+	}
+
+	if from := query.Get("from"); from != "" {
+		day, err := utils.ParseDate(from)
+		if err != nil {
+			return model.Filter{}, ierrors.Invalid("from", fmt.Sprintf("invalid from query param, expected YYYY-MM-DD got %s", from))
+		}
+
+		filter.From = day
+	}
+
+	if to := query.Get("to"); to != "" {
+		day, err := utils.ParseDate(to)
+		if err != nil {
+			return model.Filter{}, ierrors.Invalid("to", fmt.Sprintf("invalid to query param, expected YYYY-MM-DD got %s", to))
+		}
+
+		filter.Until = day.AddDate(0, 0, 1)
+	}
+
+	return filter, nil
+}
+
+func rawJson(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+
+	return json.RawMessage(value)
+}
@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"tour-le-shit-go/internal/auth"
+	"tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/policy"
+
+	"github.com/gorilla/mux"
+)
+
+// User an account of the tour, PlayerId is the player the user plays as.
+type User struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	PlayerId string `json:"playerId,omitempty"`
+	Role     string `json:"role"`
+}
+
+type UserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	PlayerId string `json:"playerId"`
+	Role     string `json:"role"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Session the token to send as "Authorization: Bearer <token>" until it expires.
+type Session struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+	User      User   `json:"user"`
+}
+
+type ApiTokenRequest struct {
+	Name string `json:"name"`
+}
+
+// ApiToken Token is only returned when the token is created.
+type ApiToken struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt"`
+	Token     string `json:"token,omitempty"`
+}
+
+const ContentTypeKey = "Content-Type"
+const ContentTypeValue = "application/json"
+const CreatedStatusCode = 201
+const NoContentStatusCode = 204
+
+type Route struct {
+	s auth.Service
+}
+
+func NewAuthRoute(s auth.Service) Route {
+	return Route{s: s}
+}
+
+func (r *Route) PostLoginRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	var login LoginRequest
+
+	err := readJson(req, &login)
+	if err != nil {
+		return err
+	}
+
+	session, err := r.s.Login(req.Context(), login.Username, login.Password)
+	if err != nil {
+		return fmt.Errorf("error logging in %w", err)
+	}
+
+	return writeJson(w, http.StatusOK, Session{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt.UTC().Format(time.RFC3339),
+		User:      toUser(session.User),
+	})
+}
+
+func (r *Route) PostUsersRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	var input UserRequest
+
+	err := readJson(req, &input)
+	if err != nil {
+		return err
+	}
+
+	user, err := r.s.Register(req.Context(), model.UserInput{
+		Username: input.Username,
+		Password: input.Password,
+		PlayerId: input.PlayerId,
+		Role:     input.Role,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating user %w", err)
+	}
+
+	return writeJson(w, CreatedStatusCode, toUser(*user))
+}
+
+func (r *Route) GetMeRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	user, err := currentUser(req)
+	if err != nil {
+		return err
+	}
+
+	return writeJson(w, http.StatusOK, toUser(*user))
+}
+
+func (r *Route) GetTokensRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	user, err := currentUser(req)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := r.s.GetApiTokens(req.Context(), *user)
+	if err != nil {
+		return fmt.Errorf("error fetching api tokens %w", err)
+	}
+
+	result := make([]ApiToken, 0, len(tokens))
+	for _, t := range tokens {
+		result = append(result, toApiToken(t))
+	}
+
+	return writeJson(w, http.StatusOK, result)
+}
+
+func (r *Route) PostTokensRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	user, err := currentUser(req)
+	if err != nil {
+		return err
+	}
+
+	var input ApiTokenRequest
+
+	err = readJson(req, &input)
+	if err != nil {
+		return err
+	}
+
+	token, t, err := r.s.CreateApiToken(req.Context(), *user, input.Name)
+	if err != nil {
+		return fmt.Errorf("error creating api token %w", err)
+	}
+
+	result := toApiToken(*t)
+	result.Token = token
+
+	return writeJson(w, CreatedStatusCode, result)
+}
+
+func (r *Route) DeleteTokenRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	user, err := currentUser(req)
+	if err != nil {
+		return err
+	}
+
+	err = r.s.RevokeApiToken(req.Context(), *user, mux.Vars(req)["id"])
+	if err != nil {
+		return fmt.Errorf("error revoking api token %w", err)
+	}
+
+	w.WriteHeader(NoContentStatusCode)
+
+	return nil
+}
+
+func currentUser(req *http.Request) (*model.User, error) {
+	user := policy.UserFrom(req.Context())
+	if user == nil {
+		return nil, ierrors.Unauthorized("log in to see your account")
+	}
+
+	return user, nil
+}
+
+func readJson(req *http.Request, v any) error {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	return nil
+}
+
+func writeJson(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func toUser(u model.User) User {
+	return User{
+		Id:       u.Id,
+		Username: u.Username,
+		PlayerId: u.PlayerId,
+		Role:     u.Role,
+	}
+}
+
+func toApiToken(t model.ApiToken) ApiToken {
+	return ApiToken{
+		Id:        t.Id,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,181 @@
+package courses
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"tour-le-shit-go/internal/courses"
+	"tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/ierrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Course a course played on the tour.
+type Course struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Par   int    `json:"par"`
+	Holes []Hole `json:"holes"`
+	Tees  []Tee  `json:"tees"`
+}
+
+type CourseInput struct {
+	Name  string `json:"name"`
+	Holes []Hole `json:"holes"`
+	Tees  []Tee  `json:"tees"`
+}
+
+type Hole struct {
+	Number      int `json:"number"`
+	Par         int `json:"par"`
+	StrokeIndex int `json:"strokeIndex"`
+}
+
+type Tee struct {
+	Name         string  `json:"name"`
+	CourseRating float64 `json:"courseRating"`
+	Slope        int     `json:"slope"`
+}
+
+type Route struct {
+	s courses.Service
+}
+
+func NewCourseRoute(s courses.Service) Route {
+	return Route{s: s}
+}
+
+const ContentTypeKey = "Content-Type"
+const ContentTypeValue = "application/json"
+const NoContentStatusCode = 204
+
+func (r *Route) GetCoursesRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	all, err := r.s.GetCourses(req.Context())
+	if err != nil {
+		return fmt.Errorf("error fetching courses %w", err)
+	}
+
+	result := make([]Course, 0)
+	for _, c := range all {
+		result = append(result, toCourse(c))
+	}
+
+	return writeJson(w, result)
+}
+
+func (r *Route) GetCourseRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	course, err := r.s.GetCourse(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error fetching course %w", err)
+	}
+
+	if course == nil {
+		return ierrors.NotFound(fmt.Sprintf("course with id %s does not exist", id))
+	}
+
+	return writeJson(w, toCourse(*course))
+}
+
+func (r *Route) PutCoursesRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	input, err := readCourseInput(req)
+	if err != nil {
+		return err
+	}
+
+	course, err := r.s.CreateCourse(req.Context(), input)
+	if err != nil {
+		return fmt.Errorf("error creating course %w", err)
+	}
+
+	return writeJson(w, toCourse(*course))
+}
+
+func (r *Route) PostCourseRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	input, err := readCourseInput(req)
+	if err != nil {
+		return err
+	}
+
+	course, err := r.s.UpdateCourse(req.Context(), mux.Vars(req)["id"], input)
+	if err != nil {
+		return fmt.Errorf("error updating course %w", err)
+	}
+
+	return writeJson(w, toCourse(*course))
+}
+
+func (r *Route) DeleteCourseRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	err := r.s.DeleteCourse(req.Context(), mux.Vars(req)["id"])
+	if err != nil {
+		return fmt.Errorf("error deleting course %w", err)
+	}
+
+	w.WriteHeader(NoContentStatusCode)
+
+	return nil
+}
+
+func readCourseInput(req *http.Request) (model.CourseInput, error) {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return model.CourseInput{}, ierrors.InvalidBody(err)
+	}
+
+	var ci CourseInput
+
+	err = json.Unmarshal(b, &ci)
+	if err != nil {
+		return model.CourseInput{}, ierrors.InvalidBody(err)
+	}
+
+	input := model.CourseInput{
+		Name:  ci.Name,
+		Holes: make([]model.Hole, 0, len(ci.Holes)),
+		Tees:  make([]model.Tee, 0, len(ci.Tees)),
+	}
+
+	for _, h := range ci.Holes {
+		input.Holes = append(input.Holes, model.Hole{Number: h.Number, Par: h.Par, StrokeIndex: h.StrokeIndex})
+	}
+
+	for _, t := range ci.Tees {
+		input.Tees = append(input.Tees, model.Tee{Name: t.Name, CourseRating: t.CourseRating, Slope: t.Slope})
+	}
+
+	return input, nil
+}
+
+func toCourse(c model.Course) Course {
+	course := Course{
+		Id:    c.Id,
+		Name:  c.Name,
+		Par:   c.Par(),
+		Holes: make([]Hole, 0, len(c.Holes)),
+		Tees:  make([]Tee, 0, len(c.Tees)),
+	}
+
+	for _, h := range c.Holes {
+		course.Holes = append(course.Holes, Hole{Number: h.Number, Par: h.Par, StrokeIndex: h.StrokeIndex})
+	}
+
+	for _, t := range c.Tees {
+		course.Tees = append(course.Tees, Tee{Name: t.Name, CourseRating: t.CourseRating, Slope: t.Slope})
+	}
+
+	return course
+}
+
+func writeJson(w http.ResponseWriter, v any) error {
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
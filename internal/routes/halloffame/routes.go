@@ -0,0 +1,160 @@
+package halloffame
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"tour-le-shit-go/internal/halloffame"
+	"tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Entry the champion and the shit holder of a closed season.
+type Entry struct {
+	Season     int       `json:"season"`
+	Name       string    `json:"name"`
+	ClosedOn   string    `json:"closedOn"`
+	Champion   *Standing `json:"champion"`
+	ShitHolder *Standing `json:"shitHolder"`
+}
+
+// FinalStandings the frozen scoreboard of a closed season.
+type FinalStandings struct {
+	Season    int        `json:"season"`
+	Name      string     `json:"name"`
+	ClosedOn  string     `json:"closedOn"`
+	Standings []Standing `json:"standings"`
+}
+
+type Standing struct {
+	Position   int    `json:"position"`
+	Shared     bool   `json:"shared"`
+	PlayerId   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Points     int    `json:"points"`
+	LastPlayed string `json:"lastPlayed"`
+}
+
+const ContentTypeKey = "Content-Type"
+const ContentTypeValue = "application/json"
+
+type Route struct {
+	s halloffame.Service
+}
+
+func NewHallOfFameRoute(s halloffame.Service) Route {
+	return Route{s: s}
+}
+
+func (r *Route) HallOfFameRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	all, err := r.s.GetHallOfFame(req.Context())
+	if err != nil {
+		return fmt.Errorf("error fetching hall of fame %w", err)
+	}
+
+	result := make([]Entry, 0)
+	for _, f := range all {
+		result = append(result, Entry{
+			Season:     f.Season,
+			Name:       f.Name,
+			ClosedOn:   utils.FormatDate(f.ClosedOn),
+			Champion:   toStandingPointer(f.Champion()),
+			ShitHolder: toStandingPointer(f.ShitHolder()),
+		})
+	}
+
+	return writeJson(w, result)
+}
+
+func (r *Route) FinalStandingsRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	season, err := seasonId(req)
+	if err != nil {
+		return err
+	}
+
+	f, err := r.s.GetFinalStandings(req.Context(), season)
+	if err != nil {
+		return fmt.Errorf("error fetching final standings %w", err)
+	}
+
+	if f == nil {
+		return ierrors.NotFound(fmt.Sprintf("season %d has not been closed", season))
+	}
+
+	return writeJson(w, toFinalStandings(*f))
+}
+
+func (r *Route) CloseSeasonRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	season, err := seasonId(req)
+	if err != nil {
+		return err
+	}
+
+	f, err := r.s.CloseSeason(req.Context(), season)
+	if err != nil {
+		return fmt.Errorf("error closing season %w", err)
+	}
+
+	return writeJson(w, toFinalStandings(*f))
+}
+
+func seasonId(req *http.Request) (int, error) {
+	id := mux.Vars(req)["id"]
+
+	sint, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, ierrors.Invalid("id", fmt.Sprintf("invalid season id, expected integer got %s", id))
+	}
+
+	return sint, nil
+}
+
+func toFinalStandings(f model.FinalStandings) FinalStandings {
+	standings := make([]Standing, 0, len(f.Standings))
+	for _, s := range f.Standings {
+		standings = append(standings, toStanding(s))
+	}
+
+	return FinalStandings{
+		Season:    f.Season,
+		Name:      f.Name,
+		ClosedOn:  utils.FormatDate(f.ClosedOn),
+		Standings: standings,
+	}
+}
+
+func toStanding(s model.Standing) Standing {
+	return Standing{
+		Position:   s.Position,
+		Shared:     s.Shared,
+		PlayerId:   s.PlayerId,
+		PlayerName: s.PlayerName,
+		Points:     s.Points,
+		LastPlayed: utils.FormatDate(s.LastPlayed),
+	}
+}
+
+func toStandingPointer(s *model.Standing) *Standing {
+	if s == nil {
+		return nil
+	}
+
+	standing := toStanding(*s)
+
+	return &standing
+}
+
+func writeJson(w http.ResponseWriter, v any) error {
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
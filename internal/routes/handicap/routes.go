@@ -0,0 +1,81 @@
+package handicap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"tour-le-shit-go/internal/handicap"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Handicap the handicap index of a member, null until enough rounds have been played.
+type Handicap struct {
+	PlayerId         string     `json:"playerId"`
+	HandicapIndex    *float64   `json:"handicapIndex"`
+	LowHandicapIndex *float64   `json:"lowHandicapIndex"`
+	History          []Revision `json:"history"`
+}
+
+type Revision struct {
+	ScoreId            string   `json:"scoreId"`
+	Day                string   `json:"day"`
+	CourseId           string   `json:"courseId"`
+	Tee                string   `json:"tee"`
+	AdjustedGrossScore int      `json:"adjustedGrossScore"`
+	Differential       float64  `json:"differential"`
+	HandicapIndex      *float64 `json:"handicapIndex"`
+}
+
+const ContentTypeKey = "Content-Type"
+const ContentTypeValue = "application/json"
+
+type Route struct {
+	s handicap.Service
+}
+
+func NewHandicapRoute(s handicap.Service) Route {
+	return Route{s: s}
+}
+
+func (r *Route) HandicapRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	h, err := r.s.GetHandicap(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error fetching handicap %w", err)
+	}
+
+	if h == nil {
+		return ierrors.NotFound(fmt.Sprintf("member with id %s does not exist", id))
+	}
+
+	history := make([]Revision, 0, len(h.History))
+	for _, rev := range h.History {
+		history = append(history, Revision{
+			ScoreId:            rev.ScoreId,
+			Day:                utils.FormatDate(rev.Day),
+			CourseId:           rev.CourseId,
+			Tee:                rev.Tee,
+			AdjustedGrossScore: rev.AdjustedGrossScore,
+			Differential:       rev.Differential,
+			HandicapIndex:      rev.HandicapIndex,
+		})
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(Handicap{
+		PlayerId:         h.PlayerId,
+		HandicapIndex:    h.HandicapIndex,
+		LowHandicapIndex: h.LowHandicapIndex,
+		History:          history,
+	})
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
@@ -5,22 +5,31 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"tour-le-shit-go/internal/audit"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/players/model"
 
 	"github.com/gorilla/mux"
 )
 
 // Member the tour le shit tour.
 type Member struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	ArchivedFrom int    `json:"archivedFrom,omitempty"`
 }
 
 type MemberInput struct {
 	Name string `json:"name"`
 }
 
+// ArchiveInput Season is the first season the member no longer takes part in.
+type ArchiveInput struct {
+	Season int `json:"season"`
+}
+
 type Route struct {
 	s players.Service
 }
@@ -32,53 +41,42 @@ func NewMemberRoute(s players.Service) Route {
 const ContentTypeKey = "Content-Type"
 const ContentTypeValue = "application/json"
 
-func (r *Route) MembersRouteHandler(w http.ResponseWriter, req *http.Request) error {
-	switch req.Method {
-	case "GET":
-		return r.handleGetRequest(w)
-	case "PUT":
-		return r.handlePutReqeuest(w, req)
+func (r *Route) GetMembersRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	members, err := r.s.GetMembers(req.Context())
+	if err != nil {
+		return fmt.Errorf("error fetching members %w", err)
 	}
 
-	return ierrors.HttpError{
-		Code:       ierrors.BadRequestStatusCode,
-		Message:    "Unsupported method type",
-		InnerError: "",
+	result := make([]Member, 0)
+	for _, m := range members {
+		result = append(result, toMember(m))
 	}
-}
 
-func (r *Route) MemberRouteHandler(w http.ResponseWriter, req *http.Request) error {
-	switch req.Method {
-	case "POST":
-		return r.handlePostRequest(w, req)
-	case "DELETE":
-		return r.handleDeleteRequest(w, req)
-	}
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
 
-	return ierrors.HttpError{
-		Code:       ierrors.BadRequestStatusCode,
-		Message:    "Unsupported method type",
-		InnerError: "",
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
 	}
+
+	return nil
 }
 
-func (r *Route) handleGetRequest(w http.ResponseWriter) error {
-	members, err := r.s.GetMembers()
+func (r *Route) GetMemberRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	m, err := r.s.GetMember(req.Context(), id)
 	if err != nil {
-		return fmt.Errorf("error fetching members %w", err)
+		return fmt.Errorf("error fetching member %w", err)
 	}
 
-	result := make([]Member, 0)
-	for _, m := range members {
-		result = append(result, Member{
-			Id:   m.Id,
-			Name: m.Name,
-		})
+	if m == nil {
+		return ierrors.NotFound(fmt.Sprintf("member with id %s does not exist", id))
 	}
 
 	w.Header().Set(ContentTypeKey, ContentTypeValue)
 
-	err = json.NewEncoder(w).Encode(result)
+	err = json.NewEncoder(w).Encode(toMember(*m))
 	if err != nil {
 		return fmt.Errorf("unknown error %w", err)
 	}
@@ -86,32 +84,29 @@ func (r *Route) handleGetRequest(w http.ResponseWriter) error {
 	return nil
 }
 
-func (r *Route) handlePutReqeuest(w http.ResponseWriter, req *http.Request) error {
+func (r *Route) PutMembersRouteHandler(w http.ResponseWriter, req *http.Request) error {
 	b, err := io.ReadAll(req.Body)
 	if err != nil {
-		return ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "invalid body",
-			InnerError: err.Error(),
-		}
+		return ierrors.InvalidBody(err)
 	}
 
 	var cm MemberInput
 
 	err = json.Unmarshal(b, &cm)
 	if err != nil {
-		return ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "invalid request body",
-			InnerError: err.Error(),
-		}
+		return ierrors.InvalidBody(err)
 	}
 
-	members, err := r.s.CreateMember(cm.Name)
+	_, err = r.s.CreateMember(req.Context(), cm.Name)
 	if err != nil {
 		return fmt.Errorf("error creating member %w", err)
 	}
 
+	members, err := r.s.GetMembers(req.Context())
+	if err != nil {
+		return fmt.Errorf("error fetching members %w", err)
+	}
+
 	w.Header().Set(ContentTypeKey, ContentTypeValue)
 
 	err = json.NewEncoder(w).Encode(members)
@@ -122,30 +117,22 @@ func (r *Route) handlePutReqeuest(w http.ResponseWriter, req *http.Request) erro
 	return nil
 }
 
-func (r *Route) handlePostRequest(w http.ResponseWriter, req *http.Request) error {
+func (r *Route) PostMemberRouteHandler(w http.ResponseWriter, req *http.Request) error {
 	vars := mux.Vars(req)
 
 	b, err := io.ReadAll(req.Body)
 	if err != nil {
-		return ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "invalid body",
-			InnerError: err.Error(),
-		}
+		return ierrors.InvalidBody(err)
 	}
 
 	var m MemberInput
 
 	err = json.Unmarshal(b, &m)
 	if err != nil {
-		return ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "invalid body",
-			InnerError: err.Error(),
-		}
+		return ierrors.InvalidBody(err)
 	}
 
-	members, err := r.s.UpdateMember(vars["id"], m.Name)
+	members, err := r.s.UpdateMember(req.Context(), vars["id"], m.Name)
 	if err != nil {
 		return fmt.Errorf("error updating member %w", err)
 	}
@@ -160,9 +147,9 @@ func (r *Route) handlePostRequest(w http.ResponseWriter, req *http.Request) erro
 	return nil
 }
 
-func (r *Route) handleDeleteRequest(w http.ResponseWriter, req *http.Request) error {
+func (r *Route) DeleteMemberRouteHandler(w http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["id"]
-	members, err := r.s.DeleteMember(id)
+	members, err := r.s.DeleteMember(req.Context(), id)
 
 	if err != nil {
 		return fmt.Errorf("error deleting member %w", err)
@@ -177,3 +164,74 @@ func (r *Route) handleDeleteRequest(w http.ResponseWriter, req *http.Request) er
 
 	return nil
 }
+
+func (r *Route) PostMemberRestoreRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	members, err := r.s.RestoreMember(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error restoring member %w", err)
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(members)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func (r *Route) PostMemberArchiveRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	var input ArchiveInput
+
+	err := readJson(req, &input)
+	if err != nil {
+		return err
+	}
+
+	members, err := r.s.ArchiveMember(req.Context(), mux.Vars(req)["id"], input.Season)
+	if err != nil {
+		return fmt.Errorf("error archiving member %w", err)
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(members)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func (r *Route) PostMemberUnarchiveRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	members, err := r.s.UnarchiveMember(req.Context(), mux.Vars(req)["id"])
+	if err != nil {
+		return fmt.Errorf("error unarchiving member %w", err)
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(members)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+// AuditRepresentation stores members in the audit log as the routes return them.
+func AuditRepresentation() audit.Representation {
+	return audit.Represent(toMember)
+}
+
+func toMember(p model.Player) Member {
+	return Member{
+		Id:           p.Id,
+		Name:         p.Name,
+		Status:       p.Status,
+		ArchivedFrom: p.ArchivedFrom,
+	}
+}
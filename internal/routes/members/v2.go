@@ -0,0 +1,155 @@
+package members
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"tour-le-shit-go/internal/ierrors"
+
+	"github.com/gorilla/mux"
+)
+
+// The v2 routes create members with POST and update them with PUT or PATCH, every change returns
+// the single member instead of the whole list.
+
+const V2Path = "/v2/members"
+const CreatedStatusCode = 201
+const NoContentStatusCode = 204
+
+// MemberPatch changes the fields that are given.
+type MemberPatch struct {
+	Name *string `json:"name"`
+}
+
+func (r *Route) PostMembersV2RouteHandler(w http.ResponseWriter, req *http.Request) error {
+	var input MemberInput
+
+	err := readJson(req, &input)
+	if err != nil {
+		return err
+	}
+
+	err = validateName(input.Name)
+	if err != nil {
+		return err
+	}
+
+	created, err := r.s.CreateMember(req.Context(), input.Name)
+	if err != nil {
+		return fmt.Errorf("error creating member %w", err)
+	}
+
+	w.Header().Set("Location", V2Path+"/"+created.Id)
+
+	return writeMember(w, CreatedStatusCode, toMember(*created))
+}
+
+func (r *Route) PutMemberV2RouteHandler(w http.ResponseWriter, req *http.Request) error {
+	var input MemberInput
+
+	err := readJson(req, &input)
+	if err != nil {
+		return err
+	}
+
+	return r.update(w, req, input.Name)
+}
+
+func (r *Route) PatchMemberV2RouteHandler(w http.ResponseWriter, req *http.Request) error {
+	var patch MemberPatch
+
+	err := readJson(req, &patch)
+	if err != nil {
+		return err
+	}
+
+	id := mux.Vars(req)["id"]
+
+	m, err := r.s.GetMember(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error fetching member %w", err)
+	}
+
+	if m == nil {
+		return ierrors.NotFound(fmt.Sprintf("member with id %s does not exist", id))
+	}
+
+	name := m.Name
+	if patch.Name != nil {
+		name = *patch.Name
+	}
+
+	return r.update(w, req, name)
+}
+
+func (r *Route) DeleteMemberV2RouteHandler(w http.ResponseWriter, req *http.Request) error {
+	_, err := r.s.DeleteMember(req.Context(), mux.Vars(req)["id"])
+	if err != nil {
+		return fmt.Errorf("error deleting member %w", err)
+	}
+
+	w.WriteHeader(NoContentStatusCode)
+
+	return nil
+}
+
+func (r *Route) update(w http.ResponseWriter, req *http.Request, name string) error {
+	err := validateName(name)
+	if err != nil {
+		return err
+	}
+
+	id := mux.Vars(req)["id"]
+
+	_, err = r.s.UpdateMember(req.Context(), id, name)
+	if err != nil {
+		return fmt.Errorf("error updating member %w", err)
+	}
+
+	// the list returned by the update leaves archived members out
+	m, err := r.s.GetMember(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error fetching member %w", err)
+	}
+
+	if m == nil {
+		return ierrors.NotFound(fmt.Sprintf("member with id %s does not exist", id))
+	}
+
+	return writeMember(w, http.StatusOK, toMember(*m))
+}
+
+func readJson(req *http.Request, v any) error {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	return nil
+}
+
+func validateName(name string) error {
+	if name == "" {
+		return ierrors.Invalid("name", "name can not be empty")
+	}
+
+	return nil
+}
+
+func writeMember(w http.ResponseWriter, status int, m Member) error {
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(m)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
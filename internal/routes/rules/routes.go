@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/score"
+	"tour-le-shit-go/internal/score/model"
+)
+
+// Rules the house rules of a season.
+// Tiebreakers are applied in order, see the score package for the available names.
+type Rules struct {
+	Season            int      `json:"season"`
+	BirdieMultiplier  int      `json:"birdieMultiplier"`
+	EagleMultiplier   int      `json:"eagleMultiplier"`
+	MuliganDiminisher int      `json:"muliganDiminisher"`
+	MaxBirdies        int      `json:"maxBirdies"`
+	MaxEagles         int      `json:"maxEagles"`
+	MaxRoundPoints    int      `json:"maxRoundPoints"`
+	Tiebreakers       []string `json:"tiebreakers"`
+	CountbackRounds   int      `json:"countbackRounds"`
+	BestRounds        int      `json:"bestRounds"`
+	MinRounds         int      `json:"minRounds"`
+}
+
+const ContentTypeKey = "Content-Type"
+const ContentTypeValue = "application/json"
+
+type Route struct {
+	s score.Service
+}
+
+func NewRulesRoute(s score.Service) Route {
+	return Route{s: s}
+}
+
+func (r *Route) GetRulesRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	season := req.URL.Query().Get("season")
+
+	sint, err := strconv.Atoi(season)
+	if err != nil {
+		return ierrors.Invalid("season", fmt.Sprintf("invalid season query param, expected integer got %s", season))
+	}
+
+	rules, err := r.s.GetRules(req.Context(), sint)
+	if err != nil {
+		return fmt.Errorf("error fetching rules %w", err)
+	}
+
+	return writeRules(w, rules)
+}
+
+func (r *Route) PutRulesRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	var rulesRequest Rules
+
+	err = json.Unmarshal(b, &rulesRequest)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	rules, err := r.s.SaveRules(req.Context(), model.Rules{
+		Season:            rulesRequest.Season,
+		BirdieMultiplier:  rulesRequest.BirdieMultiplier,
+		EagleMultiplier:   rulesRequest.EagleMultiplier,
+		MuliganDiminisher: rulesRequest.MuliganDiminisher,
+		MaxBirdies:        rulesRequest.MaxBirdies,
+		MaxEagles:         rulesRequest.MaxEagles,
+		MaxRoundPoints:    rulesRequest.MaxRoundPoints,
+		Tiebreakers:       rulesRequest.Tiebreakers,
+		CountbackRounds:   rulesRequest.CountbackRounds,
+		BestRounds:        rulesRequest.BestRounds,
+		MinRounds:         rulesRequest.MinRounds,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving rules %w", err)
+	}
+
+	return writeRules(w, rules)
+}
+
+func writeRules(w http.ResponseWriter, rules model.Rules) error {
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err := json.NewEncoder(w).Encode(Rules{
+		Season:            rules.Season,
+		BirdieMultiplier:  rules.BirdieMultiplier,
+		EagleMultiplier:   rules.EagleMultiplier,
+		MuliganDiminisher: rules.MuliganDiminisher,
+		MaxBirdies:        rules.MaxBirdies,
+		MaxEagles:         rules.MaxEagles,
+		MaxRoundPoints:    rules.MaxRoundPoints,
+		Tiebreakers:       rules.Tiebreakers,
+		CountbackRounds:   rules.CountbackRounds,
+		BestRounds:        rules.BestRounds,
+		MinRounds:         rules.MinRounds,
+	})
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
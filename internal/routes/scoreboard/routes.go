@@ -6,29 +6,50 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"tour-le-shit-go/internal/handicap"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/score"
+	"tour-le-shit-go/internal/utils"
 )
 
+const GrossMode = "gross"
+const NetMode = "net"
+
 type Scoreboard struct {
-	Season  int      `json:"season"`
-	Players []Player `json:"players"`
+	Season      int      `json:"season"`
+	Mode        string   `json:"mode"`
+	Tiebreakers []string `json:"tiebreakers"`
+	Players     []Player `json:"players"`
 }
 
+// Player a row of the scoreboard. Points and position follow the requested mode while
+// both the gross and net columns are always included. Tiebreaker names the tiebreaker
+// applied against a player on the same points.
 type Player struct {
-	Id         string `json:"id"`
-	Name       string `json:"name"`
-	Position   int    `json:"position"`
-	Points     int    `json:"points"`
-	LastPlayed string `json:"lastPlayed"`
+	Id            string   `json:"id"`
+	Name          string   `json:"name"`
+	Position      int      `json:"position"`
+	PositionLabel string   `json:"positionLabel"`
+	Tiebreaker    string   `json:"tiebreaker,omitempty"`
+	Points        int      `json:"points"`
+	GrossPosition int      `json:"grossPosition"`
+	GrossPoints   int      `json:"grossPoints"`
+	NetPosition   int      `json:"netPosition"`
+	NetPoints     int      `json:"netPoints"`
+	HandicapIndex *float64 `json:"handicapIndex"`
+	LastPlayed    string   `json:"lastPlayed"`
+	Qualified     bool     `json:"qualified"`
+	CountedRounds int      `json:"countedRounds"`
+	DroppedRounds int      `json:"droppedRounds"`
 }
 
-func NewScoreboardRoute(s score.Service) Route {
-	return Route{s}
+func NewScoreboardRoute(s score.Service, h handicap.Service) Route {
+	return Route{s, h}
 }
 
 type Route struct {
 	s score.Service
+	h handicap.Service
 }
 
 func (route *Route) ScoreboardRouteHandler(w http.ResponseWriter, r *http.Request) error {
@@ -36,38 +57,79 @@ func (route *Route) ScoreboardRouteHandler(w http.ResponseWriter, r *http.Reques
 
 	sint, err := strconv.Atoi(season)
 	if err != nil {
-		return ierrors.HttpError{Code: ierrors.BadRequestStatusCode, Message: fmt.Sprintf("invalid season query param, expected integer got %s", season)}
+		return ierrors.Invalid("season", fmt.Sprintf("invalid season query param, expected integer got %s", season))
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = GrossMode
 	}
 
-	sb, err := route.s.GetScoreboard(sint)
+	if mode != GrossMode && mode != NetMode {
+		return ierrors.Invalid("mode", fmt.Sprintf("invalid mode query param, expected gross or net got %s", mode))
+	}
 
+	sb, err := route.s.GetScoreboard(r.Context(), sint)
 	if err != nil {
-		return ierrors.HttpError{Code: ierrors.ServerErrorStatusCode, Message: "server error, please contact support", InnerError: err.Error()}
+		return fmt.Errorf("error fetching scoreboard of season %d %w", sint, err)
 	}
 
-	sortedPlayerList := sb.Players
-	sort.Slice(sortedPlayerList, func(i, j int) bool {
-		if sortedPlayerList[i].Points == sortedPlayerList[j].Points {
-			return sortedPlayerList[i].LastPlayed > sortedPlayerList[j].LastPlayed
-		}
+	sb, err = route.h.ApplyNet(r.Context(), sb)
+	if err != nil {
+		return fmt.Errorf("error applying net scores to season %d %w", sint, err)
+	}
 
-		return sortedPlayerList[i].Points > sortedPlayerList[j].Points
-	})
+	rules, err := route.s.GetRules(r.Context(), sint)
+	if err != nil {
+		return fmt.Errorf("error fetching rules of season %d %w", sint, err)
+	}
+
+	grossPositions := score.Rank(sb.Players, score.GrossPoints, rules)
+	netPositions := score.Rank(sb.Players, score.NetPoints, rules)
 
 	slice := make([]Player, 0)
-	for i, playerScore := range sortedPlayerList {
-		slice = append(slice, Player{
-			Id:         playerScore.Id,
-			Name:       playerScore.Name,
-			Points:     playerScore.Points,
-			Position:   i + 1,
-			LastPlayed: playerScore.LastPlayed,
-		})
+	for _, playerScore := range sb.Players {
+		position := grossPositions[playerScore.Id]
+		if mode == NetMode {
+			position = netPositions[playerScore.Id]
+		}
+
+		player := Player{
+			Id:            playerScore.Id,
+			Name:          playerScore.Name,
+			Points:        playerScore.Points,
+			Position:      position.Position,
+			PositionLabel: position.Label(),
+			Tiebreaker:    position.Tiebreaker,
+			GrossPosition: grossPositions[playerScore.Id].Position,
+			GrossPoints:   playerScore.Points,
+			NetPosition:   netPositions[playerScore.Id].Position,
+			NetPoints:     playerScore.NetPoints,
+			HandicapIndex: playerScore.HandicapIndex,
+			LastPlayed:    utils.FormatDate(playerScore.LastPlayed),
+			Qualified:     playerScore.Qualified,
+			CountedRounds: len(playerScore.Rounds),
+			DroppedRounds: len(playerScore.DroppedRounds),
+		}
+
+		if mode == NetMode {
+			player.Points = player.NetPoints
+		}
+
+		slice = append(slice, player)
 	}
 
+	sort.SliceStable(slice, func(i, j int) bool {
+		if slice[i].Position == slice[j].Position {
+			return slice[i].Name < slice[j].Name
+		}
+
+		return slice[i].Position < slice[j].Position
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 
-	err = json.NewEncoder(w).Encode(Scoreboard{Season: sb.Season, Players: slice})
+	err = json.NewEncoder(w).Encode(Scoreboard{Season: sb.Season, Mode: mode, Tiebreakers: rules.Tiebreakers, Players: slice})
 	if err != nil {
 		return fmt.Errorf("unknown error %w", err)
 	}
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"time"
+	"tour-le-shit-go/internal/audit"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/score"
 	"tour-le-shit-go/internal/score/model"
+	"tour-le-shit-go/internal/utils"
 
 	"github.com/gorilla/mux"
 )
@@ -32,8 +35,32 @@ type ScoreResponse struct {
 	Eagles   int    `json:"eagles"`
 	Muligans int    `json:"muligans"`
 	Day      string `json:"day"`
+	CourseId string `json:"courseId,omitempty"`
+	Tee      string `json:"tee,omitempty"`
+	Handicap int    `json:"handicap,omitempty"`
+	Holes    []Hole `json:"holes,omitempty"`
 }
 
+// AuditScore a round as the audit log stores it, with the player and season it belongs to.
+type AuditScore struct {
+	Id         string `json:"id"`
+	PlayerId   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Season     int    `json:"season"`
+	Points     int    `json:"points"`
+	Birdies    int    `json:"birdies"`
+	Eagles     int    `json:"eagles"`
+	Muligans   int    `json:"muligans"`
+	Day        string `json:"day"`
+	CourseId   string `json:"courseId,omitempty"`
+	Tee        string `json:"tee,omitempty"`
+	Handicap   int    `json:"handicap,omitempty"`
+	Holes      []Hole `json:"holes,omitempty"`
+}
+
+// ScoreRequest a round either as pre-computed totals or hole by hole.
+// When holes are given points, birdies and eagles are derived from them. Holes played on
+// a known course may omit par and stroke index. Day defaults to today when omitted.
 type ScoreRequest struct {
 	PlayerId string `json:"playerId"`
 	Points   int    `json:"points"`
@@ -41,6 +68,31 @@ type ScoreRequest struct {
 	Eagles   int    `json:"eagles"`
 	Muligans int    `json:"muligans"`
 	Season   int    `json:"season"`
+	Day      string `json:"day"`
+	CourseId string `json:"courseId"`
+	Tee      string `json:"tee"`
+	Handicap int    `json:"handicap"`
+	Holes    []Hole `json:"holes"`
+}
+
+// ScoreUpdateRequest the fields to change on a round, omitted fields keep their value.
+type ScoreUpdateRequest struct {
+	Points   *int    `json:"points"`
+	Birdies  *int    `json:"birdies"`
+	Eagles   *int    `json:"eagles"`
+	Muligans *int    `json:"muligans"`
+	Day      *string `json:"day"`
+	CourseId *string `json:"courseId"`
+	Tee      *string `json:"tee"`
+	Handicap *int    `json:"handicap"`
+	Holes    *[]Hole `json:"holes"`
+}
+
+type Hole struct {
+	Number      int `json:"number"`
+	Par         int `json:"par"`
+	StrokeIndex int `json:"strokeIndex"`
+	Strokes     int `json:"strokes"`
 }
 
 const ContentTypeKey = "Content-Type"
@@ -56,43 +108,16 @@ func NewScoresRoute(s score.Service) Route {
 	return Route{s: s}
 }
 
-func (r *Route) ScoresRouteHandler(w http.ResponseWriter, req *http.Request) error {
-	switch req.Method {
-	case "GET":
-		return r.handleGetRequest(w, req)
-	case "PUT":
-		return r.handlePutRequest(w, req)
-	}
-
-	return ierrors.HttpError{
-		Code:       ierrors.BadRequestStatusCode,
-		Message:    "Unsupported method type",
-		InnerError: "",
-	}
-}
-
-func (r *Route) ScoreRouteHandler(w http.ResponseWriter, req *http.Request) error {
-	if req.Method == "DELETE" {
-		return r.handleDeleteRequest(w, req)
-	}
-
-	return ierrors.HttpError{
-		Code:       ierrors.BadRequestStatusCode,
-		Message:    "Unsupported method type",
-		InnerError: "",
-	}
-}
-
-func (r *Route) handleGetRequest(w http.ResponseWriter, req *http.Request) error {
+func (r *Route) GetScoresRouteHandler(w http.ResponseWriter, req *http.Request) error {
 	season := req.URL.Query().Get("season")
 	playerId := req.URL.Query().Get("playerId")
 
 	sint, err := strconv.Atoi(season)
 	if err != nil {
-		return ierrors.HttpError{Code: ierrors.BadRequestStatusCode, Message: fmt.Sprintf("invalid season query param, expected integer got %s", season)}
+		return ierrors.Invalid("season", fmt.Sprintf("invalid season query param, expected integer got %s", season))
 	}
 
-	scores, err := r.s.GetPlayerScoreBySeason(playerId, sint)
+	scores, err := r.s.GetPlayerScoreBySeason(req.Context(), playerId, sint)
 	if err != nil {
 		return fmt.Errorf("error fetching player score: %w", err)
 	}
@@ -105,20 +130,13 @@ func (r *Route) handleGetRequest(w http.ResponseWriter, req *http.Request) error
 	}
 
 	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].Day > scores[j].Day
+		return scores[i].Day.After(scores[j].Day)
 	})
 
 	result := make([]ScoreResponse, 0)
 
 	for _, s := range scores {
-		result = append(result, ScoreResponse{
-			Id:       s.Id,
-			Points:   s.Points,
-			Birdies:  s.Birdies,
-			Eagles:   s.Eagles,
-			Muligans: s.Muligans,
-			Day:      s.Day,
-		})
+		result = append(result, toScoreResponse(s))
 	}
 
 	response := Response{
@@ -140,34 +158,62 @@ func (r *Route) handleGetRequest(w http.ResponseWriter, req *http.Request) error
 	return nil
 }
 
-func (r *Route) handlePutRequest(w http.ResponseWriter, req *http.Request) error {
+func (r *Route) GetScoreRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	s, err := r.s.GetScore(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error fetching score %w", err)
+	}
+
+	if s == nil {
+		return ierrors.NotFound(fmt.Sprintf("score with id %s does not exist", id))
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(toScoreResponse(*s))
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func (r *Route) PutScoresRouteHandler(w http.ResponseWriter, req *http.Request) error {
 	b, err := io.ReadAll(req.Body)
 	if err != nil {
-		return ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "invalid request body",
-			InnerError: err.Error(),
-		}
+		return ierrors.InvalidBody(err)
 	}
 
 	var scoreRequest ScoreRequest
 
 	err = json.Unmarshal(b, &scoreRequest)
 	if err != nil {
-		return ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "invalid request body",
-			InnerError: err.Error(),
+		return ierrors.InvalidBody(err)
+	}
+
+	var day time.Time
+
+	if scoreRequest.Day != "" {
+		day, err = parseDay(scoreRequest.Day)
+		if err != nil {
+			return err
 		}
 	}
 
-	_, err = r.s.AddScore(model.ScoreInput{
+	_, err = r.s.AddScore(req.Context(), model.ScoreInput{
 		PlayerId: scoreRequest.PlayerId,
 		Points:   scoreRequest.Points,
 		Birdies:  scoreRequest.Birdies,
 		Eagles:   scoreRequest.Eagles,
 		Muligans: scoreRequest.Muligans,
 		Season:   scoreRequest.Season,
+		Day:      day,
+		CourseId: scoreRequest.CourseId,
+		Tee:      scoreRequest.Tee,
+		Handicap: scoreRequest.Handicap,
+		Holes:    toHoleInputs(scoreRequest.Holes),
 	})
 
 	if err != nil {
@@ -179,9 +225,67 @@ func (r *Route) handlePutRequest(w http.ResponseWriter, req *http.Request) error
 	return nil
 }
 
-func (r *Route) handleDeleteRequest(w http.ResponseWriter, req *http.Request) error {
+func (r *Route) PatchScoreRouteHandler(w http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["id"]
-	err := r.s.DeleteScore(id)
+
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	var updateRequest ScoreUpdateRequest
+
+	err = json.Unmarshal(b, &updateRequest)
+	if err != nil {
+		return ierrors.InvalidBody(err)
+	}
+
+	update := model.ScoreUpdate{
+		Points:   updateRequest.Points,
+		Birdies:  updateRequest.Birdies,
+		Eagles:   updateRequest.Eagles,
+		Muligans: updateRequest.Muligans,
+		CourseId: updateRequest.CourseId,
+		Tee:      updateRequest.Tee,
+		Handicap: updateRequest.Handicap,
+	}
+
+	if updateRequest.Day != nil {
+		day, err := parseDay(*updateRequest.Day)
+		if err != nil {
+			return err
+		}
+
+		update.Day = &day
+	}
+
+	if updateRequest.Holes != nil {
+		holes := toHoleInputs(*updateRequest.Holes)
+		update.Holes = &holes
+	}
+
+	s, err := r.s.UpdateScore(req.Context(), id, update)
+	if err != nil {
+		return fmt.Errorf("error updating score %w", err)
+	}
+
+	if s == nil {
+		return ierrors.NotFound(fmt.Sprintf("score with id %s does not exist", id))
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(toScoreResponse(*s))
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func (r *Route) DeleteScoreRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+	err := r.s.DeleteScore(req.Context(), id)
 
 	if err != nil {
 		return fmt.Errorf("error deleting score %w", err)
@@ -191,3 +295,98 @@ func (r *Route) handleDeleteRequest(w http.ResponseWriter, req *http.Request) er
 
 	return nil
 }
+
+func (r *Route) PostScoreRestoreRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	s, err := r.s.RestoreScore(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error restoring score %w", err)
+	}
+
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err = json.NewEncoder(w).Encode(toScoreResponse(*s))
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
+
+func parseDay(value string) (time.Time, error) {
+	day, err := utils.ParseDate(value)
+	if err != nil {
+		return day, ierrors.Invalid("day", fmt.Sprintf("invalid day %s, expected format yyyy-mm-dd", value))
+	}
+
+	return day, nil
+}
+
+// AuditRepresentation stores rounds in the audit log with the player and season they belong to.
+func AuditRepresentation() audit.Representation {
+	return audit.Represent(toAuditScore)
+}
+
+func toAuditScore(s model.Score) AuditScore {
+	return AuditScore{
+		Id:         s.Id,
+		PlayerId:   s.PlayerId,
+		PlayerName: s.PlayerName,
+		Season:     s.Season,
+		Points:     s.Points,
+		Birdies:    s.Birdies,
+		Eagles:     s.Eagles,
+		Muligans:   s.Muligans,
+		Day:        utils.FormatDate(s.Day),
+		CourseId:   s.CourseId,
+		Tee:        s.Tee,
+		Handicap:   s.Handicap,
+		Holes:      toHoleResponses(s.Holes),
+	}
+}
+
+func toScoreResponse(s model.Score) ScoreResponse {
+	return ScoreResponse{
+		Id:       s.Id,
+		Points:   s.Points,
+		Birdies:  s.Birdies,
+		Eagles:   s.Eagles,
+		Muligans: s.Muligans,
+		Day:      utils.FormatDate(s.Day),
+		CourseId: s.CourseId,
+		Tee:      s.Tee,
+		Handicap: s.Handicap,
+		Holes:    toHoleResponses(s.Holes),
+	}
+}
+
+func toHoleInputs(holes []Hole) []model.Hole {
+	result := make([]model.Hole, 0, len(holes))
+
+	for _, h := range holes {
+		result = append(result, model.Hole{
+			Number:      h.Number,
+			Par:         h.Par,
+			StrokeIndex: h.StrokeIndex,
+			Strokes:     h.Strokes,
+		})
+	}
+
+	return result
+}
+
+func toHoleResponses(holes []model.Hole) []Hole {
+	result := make([]Hole, 0, len(holes))
+
+	for _, h := range holes {
+		result = append(result, Hole{
+			Number:      h.Number,
+			Par:         h.Par,
+			StrokeIndex: h.StrokeIndex,
+			Strokes:     h.Strokes,
+		})
+	}
+
+	return result
+}
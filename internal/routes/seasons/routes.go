@@ -0,0 +1,194 @@
+package seasons
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/seasons"
+	"tour-le-shit-go/internal/seasons/model"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Season a season of the tour, dates are formatted as yyyy-mm-dd.
+type Season struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Status    string `json:"status"`
+}
+
+type Route struct {
+	s seasons.Service
+}
+
+func NewSeasonRoute(s seasons.Service) Route {
+	return Route{s: s}
+}
+
+const ContentTypeKey = "Content-Type"
+const ContentTypeValue = "application/json"
+
+func (r *Route) CurrentSeasonRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	season, err := r.s.GetCurrentSeason(req.Context())
+	if err != nil {
+		return fmt.Errorf("error fetching current season %w", err)
+	}
+
+	if season == nil {
+		return ierrors.NotFound("there is no open season right now")
+	}
+
+	return writeJson(w, toSeason(*season))
+}
+
+func (r *Route) GetSeasonsRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	all, err := r.s.GetSeasons(req.Context())
+	if err != nil {
+		return fmt.Errorf("error fetching seasons %w", err)
+	}
+
+	result := make([]Season, 0)
+	for _, s := range all {
+		result = append(result, toSeason(s))
+	}
+
+	return writeJson(w, result)
+}
+
+func (r *Route) GetSeasonRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id, err := seasonId(req)
+	if err != nil {
+		return err
+	}
+
+	season, err := r.s.GetSeason(req.Context(), id)
+	if err != nil {
+		return fmt.Errorf("error fetching season %w", err)
+	}
+
+	if season == nil {
+		return ierrors.NotFound(fmt.Sprintf("season %d does not exist", id))
+	}
+
+	return writeJson(w, toSeason(*season))
+}
+
+func (r *Route) PutSeasonsRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	input, err := readSeason(req)
+	if err != nil {
+		return err
+	}
+
+	season, err := r.s.CreateSeason(req.Context(), input)
+	if err != nil {
+		return fmt.Errorf("error creating season %w", err)
+	}
+
+	return writeJson(w, toSeason(*season))
+}
+
+func (r *Route) PostSeasonRouteHandler(w http.ResponseWriter, req *http.Request) error {
+	id, err := seasonId(req)
+	if err != nil {
+		return err
+	}
+
+	input, err := readSeason(req)
+	if err != nil {
+		return err
+	}
+
+	input.Id = id
+
+	season, err := r.s.UpdateSeason(req.Context(), input)
+	if err != nil {
+		return fmt.Errorf("error updating season %w", err)
+	}
+
+	return writeJson(w, toSeason(*season))
+}
+
+func seasonId(req *http.Request) (int, error) {
+	id := mux.Vars(req)["id"]
+
+	sint, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, ierrors.Invalid("id", fmt.Sprintf("invalid season id, expected integer got %s", id))
+	}
+
+	return sint, nil
+}
+
+func readSeason(req *http.Request) (model.Season, error) {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return model.Season{}, ierrors.InvalidBody(err)
+	}
+
+	var s Season
+
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		return model.Season{}, ierrors.InvalidBody(err)
+	}
+
+	start, err := parseDate("startDate", "start date", s.StartDate)
+	if err != nil {
+		return model.Season{}, err
+	}
+
+	end, err := parseDate("endDate", "end date", s.EndDate)
+	if err != nil {
+		return model.Season{}, err
+	}
+
+	return model.Season{
+		Id:        s.Id,
+		Name:      s.Name,
+		StartDate: start,
+		EndDate:   end,
+		Status:    s.Status,
+	}, nil
+}
+
+// parseDate parses the date of a field, a missing date is left to the validation of the season.
+func parseDate(field, name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	day, err := utils.ParseDate(value)
+	if err != nil {
+		return day, ierrors.Invalid(field, fmt.Sprintf("%s must be formatted as yyyy-mm-dd", name))
+	}
+
+	return day, nil
+}
+
+func toSeason(s model.Season) Season {
+	return Season{
+		Id:        s.Id,
+		Name:      s.Name,
+		StartDate: utils.FormatDate(s.StartDate),
+		EndDate:   utils.FormatDate(s.EndDate),
+		Status:    s.Status,
+	}
+}
+
+func writeJson(w http.ResponseWriter, v any) error {
+	w.Header().Set(ContentTypeKey, ContentTypeValue)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		return fmt.Errorf("unknown error %w", err)
+	}
+
+	return nil
+}
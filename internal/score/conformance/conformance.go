@@ -0,0 +1,552 @@
+// Package conformance verifies that an implementation of score.Repository behaves like every
+// other backend.
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/score"
+	"tour-le-shit-go/internal/score/model"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// NewRepositories returns an empty score repository together with the players repository it
+// reads its players from.
+type NewRepositories func(t *testing.T) (players.Repository, score.Repository)
+
+// Run runs the suite against the repositories returned by newRepositories.
+func Run(t *testing.T, newRepositories NewRepositories) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("added score is returned with every field", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+
+		holes := []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 3}, {Number: 2, Par: 3, StrokeIndex: 2, Strokes: 3}}
+		added := addScore(t, r, model.ScoreInput{
+			PlayerId: playerId, Points: 10, Birdies: 1, Eagles: 2, Muligans: 3, Season: 1, Day: day("2022-01-01"), Holes: holes,
+		})
+
+		rounds, err := r.GetPlayerScore(ctx, playerId, 1)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if len(rounds) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(rounds))
+		}
+
+		got := rounds[0]
+		if got.Id != added.Id || got.PlayerId != playerId || got.PlayerName != "Alice" || got.Points != 10 ||
+			got.Birdies != 1 || got.Eagles != 2 || got.Muligans != 3 || got.Season != 1 || !got.Day.Equal(day("2022-01-01")) {
+			t.Errorf("expected %v got %v", added, got)
+		}
+
+		if len(got.Holes) != 2 || got.Holes[0] != holes[0] || got.Holes[1] != holes[1] {
+			t.Errorf("expected holes %v got %v", holes, got.Holes)
+		}
+
+		if !got.CreatedAt.Equal(at) || !got.UpdatedAt.Equal(at) {
+			t.Errorf("expected created and updated at %v got %v and %v", at, got.CreatedAt, got.UpdatedAt)
+		}
+	})
+	t.Run("score of unknown player is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+
+		_, err := r.AddScore(ctx, uuid.New().String(), model.ScoreInput{PlayerId: "unknown", Season: 1, Day: day("2022-01-01")}, at)
+		if ierrors.KindOf(err) != ierrors.KindValidation {
+			t.Errorf("expected validation error got %v", err)
+		}
+	})
+	t.Run("duplicate rounds are kept as separate scores", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		input := model.ScoreInput{PlayerId: playerId, Points: 10, Season: 1, Day: day("2022-01-01")}
+
+		first := addScore(t, r, input)
+		second := addScore(t, r, input)
+
+		if first.Id == second.Id {
+			t.Errorf("expected different ids got %s twice", first.Id)
+		}
+
+		rounds, _ := r.GetPlayerRounds(ctx, playerId)
+		if len(rounds) != 2 {
+			t.Errorf("expected %d got %d", 2, len(rounds))
+		}
+	})
+	t.Run("rounds are ordered by day", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+
+		for _, d := range []string{"2022-03-01", "2021-12-01", "2022-01-01"} {
+			season := 2
+			if d < "2022-01-01" {
+				season = 1
+			}
+
+			addScore(t, r, model.ScoreInput{PlayerId: playerId, Season: season, Day: day(d)})
+		}
+
+		rounds, _ := r.GetPlayerRounds(ctx, playerId)
+		assertDays(t, rounds, "2021-12-01", "2022-01-01", "2022-03-01")
+
+		rounds, _ = r.GetPlayerScore(ctx, playerId, 2)
+		assertDays(t, rounds, "2022-01-01", "2022-03-01")
+	})
+	t.Run("rounds of every player and of the scoreboard come with their holes", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		alice := createPlayer(t, p, "Alice")
+		bob := createPlayer(t, p, "Bob")
+		holes := []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 5}, {Number: 2, Par: 3, StrokeIndex: 2, Strokes: 3}}
+		addScore(t, r, model.ScoreInput{PlayerId: bob, Season: 2, Day: day("2022-03-01")})
+		addScore(t, r, model.ScoreInput{PlayerId: alice, Season: 1, Day: day("2021-12-01"), Handicap: 18, Holes: holes})
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: bob, Season: 1, Day: day("2021-12-02")})
+
+		_ = r.DeleteScore(ctx, deleted.Id, at)
+
+		rounds, err := r.GetRounds(ctx)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		assertDays(t, rounds, "2021-12-01", "2022-03-01")
+
+		if len(rounds) == 2 && (rounds[0].PlayerId != alice || len(rounds[0].Holes) != 2 || rounds[0].Handicap != 18) {
+			t.Errorf("expected the round of Alice with its holes got %v", rounds[0])
+		}
+
+		sb, _ := r.GetScoreboard(ctx, 1)
+		if len(sb.Players) != 2 || len(sb.Players[0].Rounds) != 1 || len(sb.Players[0].Rounds[0].Holes) != 2 || sb.Players[0].Rounds[0].Handicap != 18 {
+			t.Errorf("expected the round of Alice with its holes on the scoreboard got %v", sb.Players)
+		}
+	})
+	t.Run("empty season has no rounds and every player on the scoreboard", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		bob := createPlayer(t, p, "Bob")
+		alice := createPlayer(t, p, "Alice")
+		addScore(t, r, model.ScoreInput{PlayerId: bob, Points: 10, Season: 1, Day: day("2022-01-01")})
+
+		rounds, err := r.GetPlayerScore(ctx, bob, 2)
+		if err != nil || len(rounds) != 0 {
+			t.Errorf("expected no rounds and no error got %d and %v", len(rounds), err)
+		}
+
+		sb, err := r.GetScoreboard(ctx, 2)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if sb.Season != 2 || len(sb.Players) != 2 {
+			t.Fatalf("expected season %d with %d players got season %d with %d players", 2, 2, sb.Season, len(sb.Players))
+		}
+
+		for _, player := range sb.Players {
+			if len(player.Rounds) != 0 {
+				t.Errorf("expected %s without rounds got %d", player.Name, len(player.Rounds))
+			}
+		}
+
+		sb, _ = r.GetScoreboard(ctx, 1)
+		if len(sb.Players) != 2 || sb.Players[0].Id != alice || sb.Players[1].Id != bob {
+			t.Fatalf("expected Alice and Bob ordered by name got %v", sb.Players)
+		}
+
+		if len(sb.Players[0].Rounds) != 0 || len(sb.Players[1].Rounds) != 1 || sb.Players[1].Rounds[0].Points != 10 {
+			t.Errorf("expected only Bob to have a round got %v", sb.Players)
+		}
+	})
+	t.Run("delete removes only the score", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: playerId, Points: 1, Season: 1, Day: day("2022-01-01")})
+		kept := addScore(t, r, model.ScoreInput{PlayerId: playerId, Points: 10, Birdies: 1, Eagles: 2, Muligans: 3, Season: 1, Day: day("2022-01-02")})
+
+		err := r.DeleteScore(ctx, deleted.Id, at)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		rounds, _ := r.GetPlayerScore(ctx, playerId, 1)
+		if len(rounds) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(rounds))
+		}
+
+		got := rounds[0]
+		if got.Id != kept.Id || got.Points != 10 || got.Birdies != 1 || got.Eagles != 2 || got.Muligans != 3 {
+			t.Errorf("expected %v got %v", kept, got)
+		}
+	})
+	t.Run("update replaces the round", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		added := addScore(t, r, model.ScoreInput{
+			PlayerId: playerId, Points: 10, Season: 1, Day: day("2022-01-01"),
+			Holes: []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 4}},
+		})
+
+		added.Points = 12
+		added.Muligans = 1
+		added.Day = day("2022-01-05")
+		added.Holes = []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 3}, {Number: 2, Par: 4, StrokeIndex: 2, Strokes: 5}}
+		added.UpdatedAt = at.Add(time.Hour)
+
+		_, err := r.UpdateScore(ctx, *added)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, err := r.GetScore(ctx, added.Id)
+		if err != nil || got == nil {
+			t.Fatalf("expected score and no error got %v and %v", got, err)
+		}
+
+		if got.Points != 12 || got.Muligans != 1 || !got.Day.Equal(day("2022-01-05")) || len(got.Holes) != 2 || got.Holes[0].Strokes != 3 {
+			t.Errorf("expected %v got %v", added, got)
+		}
+
+		if !got.CreatedAt.Equal(at) || !got.UpdatedAt.Equal(at.Add(time.Hour)) {
+			t.Errorf("expected created at %v and updated at %v got %v and %v", at, at.Add(time.Hour), got.CreatedAt, got.UpdatedAt)
+		}
+	})
+	t.Run("unknown score is not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+
+		got, err := r.GetScore(ctx, "unknown")
+		if err != nil || got != nil {
+			t.Errorf("expected no score and no error got %v and %v", got, err)
+		}
+
+		err = r.DeleteScore(ctx, "unknown", at)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+	})
+	t.Run("deleting a player deletes their scores", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		alice := createPlayer(t, p, "Alice")
+		bob := createPlayer(t, p, "Bob")
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: alice, Season: 1, Day: day("2022-01-01"), Holes: []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 4}}})
+		addScore(t, r, model.ScoreInput{PlayerId: bob, Season: 1, Day: day("2022-01-01")})
+
+		_, err := p.DeletePlayer(ctx, alice, at)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		rounds, _ := r.GetPlayerRounds(ctx, alice)
+		if len(rounds) != 0 {
+			t.Errorf("expected %d got %d", 0, len(rounds))
+		}
+
+		got, _ := r.GetScore(ctx, deleted.Id)
+		if got != nil {
+			t.Errorf("expected no score got %v", got)
+		}
+
+		sb, _ := r.GetScoreboard(ctx, 1)
+		if len(sb.Players) != 1 || sb.Players[0].Id != bob {
+			t.Errorf("expected only Bob on the scoreboard got %v", sb.Players)
+		}
+	})
+	t.Run("deleted score is hidden until restored", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		holes := []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 4}}
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: playerId, Points: 10, Season: 1, Day: day("2022-01-01"), Holes: holes})
+
+		err := r.DeleteScore(ctx, deleted.Id, at)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, _ := r.GetScore(ctx, deleted.Id)
+		sb, _ := r.GetScoreboard(ctx, 1)
+
+		if got != nil || len(sb.Players) != 1 || len(sb.Players[0].Rounds) != 0 {
+			t.Errorf("expected the score to be hidden got %v and %v", got, sb.Players)
+		}
+
+		err = r.DeleteScore(ctx, deleted.Id, at)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+
+		got, err = r.GetDeletedScore(ctx, deleted.Id)
+		if err != nil || got == nil || got.PlayerId != playerId {
+			t.Errorf("expected deleted score and no error got %v and %v", got, err)
+		}
+
+		restored, err := r.RestoreScore(ctx, deleted.Id)
+		if err != nil || restored == nil || restored.Points != 10 || restored.PlayerName != "Alice" || len(restored.Holes) != 1 {
+			t.Fatalf("expected restored score and no error got %v and %v", restored, err)
+		}
+
+		rounds, _ := r.GetPlayerScore(ctx, playerId, 1)
+		if len(rounds) != 1 || rounds[0].Id != deleted.Id || len(rounds[0].Holes) != 1 {
+			t.Errorf("expected the restored score got %v", rounds)
+		}
+
+		_, err = r.RestoreScore(ctx, deleted.Id)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+	})
+	t.Run("restoring a player restores their scores", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		alice := createPlayer(t, p, "Alice")
+		added := addScore(t, r, model.ScoreInput{PlayerId: alice, Points: 10, Season: 1, Day: day("2022-01-01")})
+
+		_, _ = p.DeletePlayer(ctx, alice, at)
+
+		_, err := p.RestorePlayer(ctx, alice)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		got, _ := r.GetScore(ctx, added.Id)
+		sb, _ := r.GetScoreboard(ctx, 1)
+
+		if got == nil || len(sb.Players) != 1 || len(sb.Players[0].Rounds) != 1 {
+			t.Errorf("expected the score of Alice got %v and %v", got, sb.Players)
+		}
+	})
+	t.Run("score of a deleted player can not be restored", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		alice := createPlayer(t, p, "Alice")
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: alice, Season: 1, Day: day("2022-01-01")})
+
+		_ = r.DeleteScore(ctx, deleted.Id, at)
+		_, _ = p.DeletePlayer(ctx, alice, at)
+
+		_, err := r.RestoreScore(ctx, deleted.Id)
+		if ierrors.KindOf(err) != ierrors.KindConflict {
+			t.Errorf("expected conflict error got %v", err)
+		}
+	})
+	t.Run("purge removes scores deleted before the given time", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: playerId, Season: 1, Day: day("2022-01-01"), Holes: []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 4}}})
+		kept := addScore(t, r, model.ScoreInput{PlayerId: playerId, Season: 1, Day: day("2022-01-02")})
+
+		_ = r.DeleteScore(ctx, deleted.Id, at)
+
+		purged, err := r.PurgeScores(ctx, at.Add(-time.Hour))
+		if err != nil || purged != 0 {
+			t.Errorf("expected %d purged and no error got %d and %v", 0, purged, err)
+		}
+
+		purged, err = r.PurgeScores(ctx, at.Add(time.Hour))
+		if err != nil || purged != 1 {
+			t.Errorf("expected %d purged and no error got %d and %v", 1, purged, err)
+		}
+
+		got, _ := r.GetDeletedScore(ctx, deleted.Id)
+		if got != nil {
+			t.Errorf("expected no deleted score got %v", got)
+		}
+
+		rounds, _ := r.GetPlayerRounds(ctx, playerId)
+		if len(rounds) != 1 || rounds[0].Id != kept.Id {
+			t.Errorf("expected only the kept score got %v", rounds)
+		}
+	})
+	t.Run("purging a player removes their scores", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		alice := createPlayer(t, p, "Alice")
+		bob := createPlayer(t, p, "Bob")
+		deleted := addScore(t, r, model.ScoreInput{PlayerId: alice, Season: 1, Day: day("2022-01-01")})
+		addScore(t, r, model.ScoreInput{PlayerId: alice, Season: 1, Day: day("2022-01-02")})
+		kept := addScore(t, r, model.ScoreInput{PlayerId: bob, Season: 1, Day: day("2022-01-01")})
+
+		_ = r.DeleteScore(ctx, deleted.Id, at)
+		_, _ = p.DeletePlayer(ctx, alice, at)
+
+		purged, err := p.PurgePlayers(ctx, at.Add(time.Hour))
+		if err != nil || purged != 1 {
+			t.Fatalf("expected %d purged and no error got %d and %v", 1, purged, err)
+		}
+
+		got, _ := r.GetDeletedScore(ctx, deleted.Id)
+		if got != nil {
+			t.Errorf("expected no deleted score got %v", got)
+		}
+
+		_, err = r.RestoreScore(ctx, deleted.Id)
+		if ierrors.KindOf(err) != ierrors.KindNotFound {
+			t.Errorf("expected notfound error got %v", err)
+		}
+
+		purged, err = r.PurgeScores(ctx, at.Add(time.Hour))
+		if err != nil || purged != 0 {
+			t.Errorf("expected %d purged and no error got %d and %v", 0, purged, err)
+		}
+
+		rounds, _ := r.GetPlayerRounds(ctx, bob)
+		if len(rounds) != 1 || rounds[0].Id != kept.Id {
+			t.Errorf("expected the score of Bob to be kept got %v", rounds)
+		}
+	})
+	t.Run("handicap of a round is kept", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		playerId := createPlayer(t, p, "Alice")
+		added := addScore(t, r, model.ScoreInput{PlayerId: playerId, Points: 36, Season: 1, Day: day("2022-01-01"), Handicap: 12})
+
+		got, _ := r.GetScore(ctx, added.Id)
+		sb, _ := r.GetScoreboard(ctx, 1)
+
+		if got == nil || got.Handicap != 12 || len(sb.Players) != 1 || sb.Players[0].Rounds[0].Handicap != 12 {
+			t.Errorf("expected handicap %d got %v and %v", 12, got, sb.Players)
+		}
+	})
+	t.Run("archived player is only on the scoreboards of earlier seasons", func(t *testing.T) {
+		t.Parallel()
+
+		p, r := newRepositories(t)
+		alice := createPlayer(t, p, "Alice")
+		createPlayer(t, p, "Bob")
+		addScore(t, r, model.ScoreInput{PlayerId: alice, Points: 10, Season: 1, Day: day("2022-01-01")})
+
+		_, err := p.ArchivePlayer(ctx, alice, 2)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		past, _ := r.GetScoreboard(ctx, 1)
+		if len(past.Players) != 2 || past.Players[0].Name != "Alice" || len(past.Players[0].Rounds) != 1 {
+			t.Errorf("expected Alice with her round and Bob got %v", past.Players)
+		}
+
+		current, _ := r.GetScoreboard(ctx, 2)
+		if len(current.Players) != 1 || current.Players[0].Name != "Bob" {
+			t.Errorf("expected Bob got %v", current.Players)
+		}
+
+		_, err = r.AddScore(ctx, uuid.New().String(), model.ScoreInput{PlayerId: alice, Season: 2, Day: day("2023-01-01")}, at)
+		if ierrors.KindOf(err) != ierrors.KindValidation {
+			t.Errorf("expected validation error got %v", err)
+		}
+
+		addScore(t, r, model.ScoreInput{PlayerId: alice, Season: 1, Day: day("2022-01-02")})
+	})
+	t.Run("rules are missing until saved and saving again replaces them", func(t *testing.T) {
+		t.Parallel()
+
+		_, r := newRepositories(t)
+
+		rules, err := r.GetRules(ctx, 1)
+		if err != nil || rules != nil {
+			t.Fatalf("expected no rules and no error got %v and %v", rules, err)
+		}
+
+		saved := score.DefaultRules(1)
+		saved.MaxBirdies = 3
+		saved.BestRounds = 5
+		saved.Tiebreakers = []string{score.TiebreakBirdies, score.TiebreakShared}
+
+		for _, multiplier := range []int{4, 5} {
+			saved.BirdieMultiplier = multiplier
+
+			err = r.SaveRules(ctx, saved)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+		}
+
+		rules, _ = r.GetRules(ctx, 1)
+		if rules == nil || rules.BirdieMultiplier != 5 || rules.MaxBirdies != 3 || rules.BestRounds != 5 ||
+			len(rules.Tiebreakers) != 2 || rules.Tiebreakers[1] != score.TiebreakShared {
+			t.Errorf("expected %v got %v", saved, rules)
+		}
+
+		other, _ := r.GetRules(ctx, 2)
+		if other != nil {
+			t.Errorf("expected no rules for season %d got %v", 2, other)
+		}
+	})
+}
+
+func createPlayer(t *testing.T, p players.Repository, name string) string {
+	t.Helper()
+
+	created, err := p.CreatePlayer(context.Background(), uuid.New().String(), name)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	return created.Id
+}
+
+// at is the time rounds are added and deleted at.
+var at = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func addScore(t *testing.T, r score.Repository, input model.ScoreInput) *model.Score {
+	t.Helper()
+
+	added, err := r.AddScore(context.Background(), uuid.New().String(), input, at)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	return added
+}
+
+func assertDays(t *testing.T, rounds []model.Score, days ...string) {
+	t.Helper()
+
+	if len(rounds) != len(days) {
+		t.Fatalf("expected %d got %d", len(days), len(rounds))
+	}
+
+	for i, d := range days {
+		if !rounds[i].Day.Equal(day(d)) {
+			t.Errorf("expected %s got %s at %d", d, utils.FormatDate(rounds[i].Day), i)
+		}
+	}
+}
+
+func day(value string) time.Time {
+	d, _ := utils.ParseDate(value)
+
+	return d
+}
@@ -1,41 +1,153 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/players"
 	"tour-le-shit-go/internal/score/model"
-	"tour-le-shit-go/internal/utils"
-
-	"github.com/google/uuid"
 )
 
 const GetPlayerScoreBySeasonQuery = `
-	SELECT s.id, s.player_id, p.name, s.points, s.birdies, s.eagles, s.muligans, s.season, s.day
-	FROM score s INNER JOIN player p on (s.player_id = p.id) 
-	WHERE s.player_id=$1 and season=$2;
+	SELECT s.id, s.player_id, p.name, s.points, s.birdies, s.eagles, s.muligans, s.season, s.day,
+		COALESCE(s.course_id, ''), COALESCE(s.tee, ''), s.handicap, s.created_at, s.updated_at
+	FROM score s INNER JOIN player p on (s.player_id = p.id)
+	WHERE s.player_id=$1 and season=$2 AND NOT s.deleted AND NOT p.deleted
+	ORDER BY s.day;
+`
+
+const GetPlayerScoresQuery = `
+	SELECT s.id, s.player_id, p.name, s.points, s.birdies, s.eagles, s.muligans, s.season, s.day,
+		COALESCE(s.course_id, ''), COALESCE(s.tee, ''), s.handicap, s.created_at, s.updated_at
+	FROM score s INNER JOIN player p on (s.player_id = p.id)
+	WHERE s.player_id=$1 AND NOT s.deleted AND NOT p.deleted
+	ORDER BY s.day;
+`
+
+const GetScoresQuery = `
+	SELECT s.id, s.player_id, p.name, s.points, s.birdies, s.eagles, s.muligans, s.season, s.day,
+		COALESCE(s.course_id, ''), COALESCE(s.tee, ''), s.handicap, s.created_at, s.updated_at
+	FROM score s INNER JOIN player p on (s.player_id = p.id)
+	WHERE NOT s.deleted AND NOT p.deleted
+	ORDER BY s.day;
+`
+
+const GetScoreByIdQuery = `
+	SELECT s.id, s.player_id, p.name, s.points, s.birdies, s.eagles, s.muligans, s.season, s.day,
+		COALESCE(s.course_id, ''), COALESCE(s.tee, ''), s.handicap, s.created_at, s.updated_at
+	FROM score s INNER JOIN player p on (s.player_id = p.id)
+	WHERE s.id=$1 AND NOT s.deleted AND NOT p.deleted;
+`
+
+const GetDeletedScoreByIdQuery = `
+	SELECT s.id, s.player_id, p.name, s.points, s.birdies, s.eagles, s.muligans, s.season, s.day,
+		COALESCE(s.course_id, ''), COALESCE(s.tee, ''), s.handicap, s.created_at, s.updated_at
+	FROM score s INNER JOIN player p on (s.player_id = p.id)
+	WHERE s.id=$1 AND s.deleted;
+`
+
+const GetHolesByScoreIdQuery = `
+	SELECT h.score_id, h.hole, h.par, h.stroke_index, h.strokes
+	FROM hole_score h
+	WHERE h.score_id=$1
+	ORDER BY h.hole;
+`
+
+const UpdateScoreQuery = `
+	UPDATE score SET points=$2, birdies=$3, eagles=$4, muligans=$5, day=$6, course_id=NULLIF($7, ''), tee=NULLIF($8, ''),
+		handicap=$9, updated_at=$10
+	WHERE id=$1;
+`
+
+const DeleteScoreById = `UPDATE score SET deleted = TRUE, deleted_at = $2 WHERE id=$1;`
+
+const RestoreScoreById = `UPDATE score SET deleted = FALSE, deleted_at = NULL WHERE id=$1;`
+
+const DeleteHolesByScoreId = `DELETE FROM hole_score WHERE score_id=$1;`
+
+const PurgeHolesQuery = `
+	DELETE FROM hole_score WHERE score_id IN (SELECT id FROM score WHERE deleted AND deleted_at < $1);
 `
 
-const DeleteScoreById = `DELETE FROM score WHERE id=$1;`
+const PurgeScoresQuery = `DELETE FROM score WHERE deleted AND deleted_at < $1;`
 
 const InsertScoreQuery = `
-	INSERT INTO score (id, player_id, points, birdies, eagles, muligans, season, day) 
-	VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	INSERT INTO score (id, player_id, points, birdies, eagles, muligans, season, day, course_id, tee, handicap, created_at, updated_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), $11, $12, $13)
+`
+
+const InsertHoleQuery = `
+	INSERT INTO hole_score (score_id, hole, par, stroke_index, strokes)
+	VALUES($1, $2, $3, $4, $5)
+`
+
+const GetPlayerHolesBySeasonQuery = `
+	SELECT h.score_id, h.hole, h.par, h.stroke_index, h.strokes
+	FROM hole_score h INNER JOIN score s on (h.score_id = s.id)
+	WHERE s.player_id=$1 and s.season=$2 AND NOT s.deleted
+	ORDER BY h.hole;
+`
+
+const GetPlayerHolesQuery = `
+	SELECT h.score_id, h.hole, h.par, h.stroke_index, h.strokes
+	FROM hole_score h INNER JOIN score s on (h.score_id = s.id)
+	WHERE s.player_id=$1 AND NOT s.deleted
+	ORDER BY h.hole;
+`
+
+const GetHolesQuery = `
+	SELECT h.score_id, h.hole, h.par, h.stroke_index, h.strokes
+	FROM hole_score h INNER JOIN score s on (h.score_id = s.id)
+	WHERE NOT s.deleted
+	ORDER BY h.hole;
+`
+
+const GetHolesBySeasonQuery = `
+	SELECT h.score_id, h.hole, h.par, h.stroke_index, h.strokes
+	FROM hole_score h INNER JOIN score s on (h.score_id = s.id)
+	WHERE s.season=$1 AND NOT s.deleted
+	ORDER BY h.hole;
 `
 
+// GetScoreboardQuery archived players are only on the scoreboards of the seasons before they left.
 const GetScoreboardQuery = `
-	WITH player_points as (
-        SELECT s.player_id, sum(s.points) + 2*SUM(s.birdies) + 3*SUM(s.eagles) - 3*SUM(s.muligans) AS points, MAX(s.day) AS day
-        FROM score s
-        WHERE season=$1
-        GROUP BY s.player_id
-	)
-	SELECT COALESCE(pp.points, 0), p.id AS player_id, p.name, COALESCE(pp.day, '') AS last_played 
-	FROM player_points pp 
-	RIGHT JOIN player p ON (pp.player_id = p.id);
+	SELECT p.id, p.name, s.id, s.points, s.birdies, s.eagles, s.muligans, s.day, s.course_id, s.tee, s.handicap
+	FROM player p
+	LEFT JOIN score s ON (s.player_id = p.id AND s.season = $1 AND NOT s.deleted)
+	WHERE NOT p.deleted AND (p.archived_from IS NULL OR p.archived_from > $1)
+	ORDER BY p.name, s.day;
+`
+
+const GetRulesQuery = `
+	SELECT season, birdie_multiplier, eagle_multiplier, muligan_diminisher, max_birdies, max_eagles, max_round_points,
+		tiebreakers, countback_rounds, best_rounds, min_rounds
+	FROM rules WHERE season=$1;
 `
 
+const UpsertRulesQuery = `
+	INSERT INTO rules (season, birdie_multiplier, eagle_multiplier, muligan_diminisher, max_birdies, max_eagles, max_round_points,
+		tiebreakers, countback_rounds, best_rounds, min_rounds)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (season) DO UPDATE SET
+		birdie_multiplier = EXCLUDED.birdie_multiplier,
+		eagle_multiplier = EXCLUDED.eagle_multiplier,
+		muligan_diminisher = EXCLUDED.muligan_diminisher,
+		max_birdies = EXCLUDED.max_birdies,
+		max_eagles = EXCLUDED.max_eagles,
+		max_round_points = EXCLUDED.max_round_points,
+		tiebreakers = EXCLUDED.tiebreakers,
+		countback_rounds = EXCLUDED.countback_rounds,
+		best_rounds = EXCLUDED.best_rounds,
+		min_rounds = EXCLUDED.min_rounds;
+`
+
+const TiebreakerDelimiter = ","
+
+// PostgresRepository the queries are kept portable so the repository also serves the SQLite mode.
 type PostgresRepository struct {
 	db                *sql.DB
 	playersRepository players.Repository
@@ -45,76 +157,183 @@ func NewRepository(db *sql.DB, repository players.Repository) *PostgresRepositor
 	return &PostgresRepository{db: db, playersRepository: repository}
 }
 
-func (r *PostgresRepository) GetPlayerScore(id string, season int) ([]model.Score, error) {
-	stmt, err := r.db.Prepare(GetPlayerScoreBySeasonQuery)
+func (r *PostgresRepository) GetPlayerScore(ctx context.Context, id string, season int) ([]model.Score, error) {
+	return r.queryPlayerScores(ctx, GetPlayerScoreBySeasonQuery, GetPlayerHolesBySeasonQuery, id, season)
+}
+
+func (r *PostgresRepository) GetPlayerRounds(ctx context.Context, id string) ([]model.Score, error) {
+	return r.queryPlayerScores(ctx, GetPlayerScoresQuery, GetPlayerHolesQuery, id)
+}
+
+// GetRounds returns every round of the players which are not deleted ordered by day.
+func (r *PostgresRepository) GetRounds(ctx context.Context) ([]model.Score, error) {
+	return r.queryPlayerScores(ctx, GetScoresQuery, GetHolesQuery)
+}
+
+func (r *PostgresRepository) GetScore(ctx context.Context, id string) (*model.Score, error) {
+	scores, err := r.queryPlayerScores(ctx, GetScoreByIdQuery, GetHolesByScoreIdQuery, id)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: "Error preparing statement from db " + err.Error(),
-		}
+		return nil, err
 	}
 
-	rows, err := stmt.Query(id, season)
+	if len(scores) == 0 {
+		return nil, nil
+	}
 
+	return &scores[0], nil
+}
+
+// UpdateScore replaces the totals, day, course, holes and update time of a round.
+func (r *PostgresRepository) UpdateScore(ctx context.Context, score model.Score) (*model.Score, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: "Error fetching from db: " + err.Error(),
-		}
+		return nil, ierrors.Database("Error starting transaction", err)
 	}
 
-	p, err := getPlayerScores(rows)
+	_, err = tx.ExecContext(ctx, UpdateScoreQuery, score.Id, score.Points, score.Birdies, score.Eagles, score.Muligans, score.Day, score.CourseId, score.Tee, score.Handicap, score.UpdatedAt)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: "Error parsing result from db " + err.Error(),
+		_ = tx.Rollback()
+
+		return nil, ierrors.Database("Error executing statement from db", err)
+	}
+
+	_, err = tx.ExecContext(ctx, DeleteHolesByScoreId, score.Id)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, ierrors.Database("Error deleting holes from db", err)
+	}
+
+	for _, h := range score.Holes {
+		_, err = tx.ExecContext(ctx, InsertHoleQuery, score.Id, h.Number, h.Par, h.StrokeIndex, h.Strokes)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return nil, ierrors.Database("Error inserting hole from db", err)
 		}
 	}
 
-	return p, nil
+	err = tx.Commit()
+	if err != nil {
+		return nil, ierrors.Database("Error committing transaction", err)
+	}
+
+	return &score, nil
 }
 
-func (r *PostgresRepository) DeleteScore(id string) error {
-	stmt, err := r.db.Prepare(DeleteScoreById)
+func (r *PostgresRepository) DeleteScore(ctx context.Context, id string, at time.Time) error {
+	score, err := r.GetScore(ctx, id)
 	if err != nil {
-		return ierrors.DbError{
-			Message: "Error preparing statement from db " + err.Error(),
-		}
+		return err
 	}
 
-	_, err = stmt.Exec(id)
+	if score == nil {
+		return ierrors.NotFound(fmt.Sprintf("score with id %s does not exist", id))
+	}
 
+	_, err = r.db.ExecContext(ctx, DeleteScoreById, id, at)
 	if err != nil {
-		return ierrors.DbError{
-			Message: "Error executing statement from db: " + err.Error(),
-		}
+		return ierrors.Database("Error executing statement from db", err)
 	}
 
 	return nil
 }
 
-func (r *PostgresRepository) AddScore(scoreInput model.ScoreInput) (*model.Score, error) {
-	player, err := r.playersRepository.GetPlayerById(scoreInput.PlayerId)
+// GetDeletedScore returns a deleted round which has not been purged yet.
+func (r *PostgresRepository) GetDeletedScore(ctx context.Context, id string) (*model.Score, error) {
+	scores, err := r.queryPlayerScores(ctx, GetDeletedScoreByIdQuery, GetHolesByScoreIdQuery, id)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: fmt.Sprintf("error fetching player %v", err),
-		}
+		return nil, err
+	}
+
+	if len(scores) == 0 {
+		return nil, nil
+	}
+
+	return &scores[0], nil
+}
+
+// RestoreScore brings back a deleted round, the player of the round must not be deleted.
+func (r *PostgresRepository) RestoreScore(ctx context.Context, id string) (*model.Score, error) {
+	deleted, err := r.GetDeletedScore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("deleted score with id %s does not exist", id))
+	}
+
+	player, err := r.playersRepository.GetPlayerById(ctx, deleted.PlayerId)
+	if err != nil {
+		return nil, ierrors.Database("error fetching player", err)
 	}
 
 	if player == nil {
-		return nil, ierrors.HttpError{
-			Code:       ierrors.BadRequestStatusCode,
-			Message:    "player does not exists",
-			InnerError: "",
-		}
+		return nil, ierrors.Conflict(fmt.Sprintf("player with id %s of the score is deleted", deleted.PlayerId))
 	}
 
-	stmt, err := r.db.Prepare(InsertScoreQuery)
+	_, err = r.db.ExecContext(ctx, RestoreScoreById, id)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: "Error preparing statement from db " + err.Error(),
-		}
+		return nil, ierrors.Database("Error executing statement from db", err)
+	}
+
+	return r.GetScore(ctx, id)
+}
+
+// PurgeScores removes the rounds deleted before the given time for good and returns how many
+// were removed.
+func (r *PostgresRepository) PurgeScores(ctx context.Context, before time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, ierrors.Database("Error starting transaction", err)
+	}
+
+	_, err = tx.ExecContext(ctx, PurgeHolesQuery, before.UTC())
+	if err != nil {
+		_ = tx.Rollback()
+
+		return 0, ierrors.Database("Error purging holes from db", err)
+	}
+
+	result, err := tx.ExecContext(ctx, PurgeScoresQuery, before.UTC())
+	if err != nil {
+		_ = tx.Rollback()
+
+		return 0, ierrors.Database("Error purging scores from db", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+
+		return 0, ierrors.Database("Error reading purged scores", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, ierrors.Database("Error committing transaction", err)
+	}
+
+	return int(purged), nil
+}
+
+func (r *PostgresRepository) AddScore(ctx context.Context, id string, scoreInput model.ScoreInput, at time.Time) (*model.Score, error) {
+	player, err := r.playersRepository.GetPlayerById(ctx, scoreInput.PlayerId)
+	if err != nil {
+		return nil, ierrors.Database("error fetching player", err)
+	}
+
+	if player == nil {
+		return nil, ierrors.Invalid("playerId", "player does not exists")
+	}
+
+	if !player.ActiveIn(scoreInput.Season) {
+		return nil, ierrors.Invalid("playerId", fmt.Sprintf("player is archived from season %d", player.ArchivedFrom))
 	}
 
 	score := model.Score{
-		Id:         uuid.New().String(),
+		Id:         id,
 		PlayerId:   player.Id,
 		PlayerName: player.Name,
 		Points:     scoreInput.Points,
@@ -122,67 +341,221 @@ func (r *PostgresRepository) AddScore(scoreInput model.ScoreInput) (*model.Score
 		Eagles:     scoreInput.Eagles,
 		Muligans:   scoreInput.Muligans,
 		Season:     scoreInput.Season,
-		Day:        utils.GetToday(),
+		Day:        scoreInput.Day,
+		CourseId:   scoreInput.CourseId,
+		Tee:        scoreInput.Tee,
+		Handicap:   scoreInput.Handicap,
+		Holes:      scoreInput.Holes,
+		CreatedAt:  at,
+		UpdatedAt:  at,
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, ierrors.Database("Error starting transaction", err)
 	}
 
-	_, err = stmt.Exec(score.Id, score.PlayerId, score.Points, score.Birdies, score.Eagles, score.Muligans, score.Season, score.Day)
+	_, err = tx.ExecContext(ctx, InsertScoreQuery, score.Id, score.PlayerId, score.Points, score.Birdies, score.Eagles, score.Muligans, score.Season, score.Day, score.CourseId, score.Tee, score.Handicap, score.CreatedAt, score.UpdatedAt)
 	if err != nil {
-		return nil, ierrors.DbError{
-			Message: "Error executing statement from db: " + err.Error(),
+		_ = tx.Rollback()
+
+		return nil, ierrors.Database("Error executing statement from db", err)
+	}
+
+	for _, h := range score.Holes {
+		_, err = tx.ExecContext(ctx, InsertHoleQuery, score.Id, h.Number, h.Par, h.StrokeIndex, h.Strokes)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return nil, ierrors.Database("Error inserting hole from db", err)
 		}
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		return nil, ierrors.Database("Error committing transaction", err)
+	}
+
 	return &score, nil
 }
 
-func (r *PostgresRepository) GetScoreboard(season int) (model.Scoreboard, error) {
-	stmt, err := r.db.Prepare(GetScoreboardQuery)
+func (r *PostgresRepository) GetScoreboard(ctx context.Context, season int) (model.Scoreboard, error) {
+	rows, err := r.db.QueryContext(ctx, GetScoreboardQuery, season)
 	if err != nil {
-		return model.Scoreboard{}, ierrors.DbError{
-			Message: "Error preparing statement from db " + err.Error(),
-		}
+		return model.Scoreboard{}, ierrors.Database("Error querying db", err)
 	}
 
-	rows, err := stmt.Query(season)
-	if err != nil {
-		return model.Scoreboard{}, ierrors.DbError{
-			Message: "Error querying db " + err.Error(),
-		}
-	}
+	defer rows.Close()
 
 	players := make([]model.ScoreboardPlayer, 0)
+	indexes := make(map[string]int, 0)
 
 	for rows.Next() {
+		var playerId string
+
 		var playerName string
 
-		var playerId string
+		var scoreId sql.NullString
 
-		var points int
+		var points sql.NullInt64
+
+		var birdies sql.NullInt64
+
+		var eagles sql.NullInt64
+
+		var muligans sql.NullInt64
 
-		var lastPlayed string
+		var day sql.NullTime
 
-		err = rows.Scan(&points, &playerId, &playerName, &lastPlayed)
+		var courseId sql.NullString
+
+		var tee sql.NullString
+
+		var handicap sql.NullInt64
+
+		err = rows.Scan(&playerId, &playerName, &scoreId, &points, &birdies, &eagles, &muligans, &day, &courseId, &tee, &handicap)
 		if err != nil {
-			return model.Scoreboard{}, ierrors.DbError{
-				Message: "Error scanning rows: " + err.Error(),
-			}
+			return model.Scoreboard{}, ierrors.Database("Error scanning rows", err)
 		}
 
-		players = append(players, model.ScoreboardPlayer{
-			Id:         playerId,
-			Name:       playerName,
-			Points:     points,
-			LastPlayed: lastPlayed,
+		i, ok := indexes[playerId]
+		if !ok {
+			i = len(players)
+			indexes[playerId] = i
+
+			players = append(players, model.ScoreboardPlayer{
+				Id:     playerId,
+				Name:   playerName,
+				Rounds: make([]model.Score, 0),
+			})
+		}
+
+		if !scoreId.Valid {
+			continue
+		}
+
+		players[i].Rounds = append(players[i].Rounds, model.Score{
+			Id:         scoreId.String,
+			PlayerId:   playerId,
+			PlayerName: playerName,
+			Points:     int(points.Int64),
+			Birdies:    int(birdies.Int64),
+			Eagles:     int(eagles.Int64),
+			Muligans:   int(muligans.Int64),
+			Season:     season,
+			Day:        day.Time,
+			CourseId:   courseId.String,
+			Tee:        tee.String,
+			Handicap:   int(handicap.Int64),
 		})
 	}
 
+	if err = rows.Err(); err != nil {
+		return model.Scoreboard{}, ierrors.Database("Error reading rows", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return model.Scoreboard{}, ierrors.Database("Error closing rows", err)
+	}
+
+	holes, err := r.queryHoles(ctx, GetHolesBySeasonQuery, season)
+	if err != nil {
+		return model.Scoreboard{}, err
+	}
+
+	for i := range players {
+		for j := range players[i].Rounds {
+			players[i].Rounds[j].Holes = holes[players[i].Rounds[j].Id]
+		}
+	}
+
 	return model.Scoreboard{
 		Players: players,
 		Season:  season,
 	}, nil
 }
 
+func (r *PostgresRepository) GetRules(ctx context.Context, season int) (*model.Rules, error) {
+	var rules model.Rules
+
+	var tiebreakers string
+
+	err := r.db.QueryRowContext(ctx, GetRulesQuery, season).Scan(
+		&rules.Season,
+		&rules.BirdieMultiplier,
+		&rules.EagleMultiplier,
+		&rules.MuliganDiminisher,
+		&rules.MaxBirdies,
+		&rules.MaxEagles,
+		&rules.MaxRoundPoints,
+		&tiebreakers,
+		&rules.CountbackRounds,
+		&rules.BestRounds,
+		&rules.MinRounds,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ierrors.Database("Error scanning rules", err)
+	}
+
+	rules.Tiebreakers = make([]string, 0)
+	if tiebreakers != "" {
+		rules.Tiebreakers = strings.Split(tiebreakers, TiebreakerDelimiter)
+	}
+
+	return &rules, nil
+}
+
+func (r *PostgresRepository) SaveRules(ctx context.Context, rules model.Rules) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		UpsertRulesQuery,
+		rules.Season,
+		rules.BirdieMultiplier,
+		rules.EagleMultiplier,
+		rules.MuliganDiminisher,
+		rules.MaxBirdies,
+		rules.MaxEagles,
+		rules.MaxRoundPoints,
+		strings.Join(rules.Tiebreakers, TiebreakerDelimiter),
+		rules.CountbackRounds,
+		rules.BestRounds,
+		rules.MinRounds,
+	)
+	if err != nil {
+		return ierrors.Database("Error executing statement from db", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) queryPlayerScores(ctx context.Context, scoreQuery, holesQuery string, args ...any) ([]model.Score, error) {
+	rows, err := r.db.QueryContext(ctx, scoreQuery, args...)
+	if err != nil {
+		return nil, ierrors.Database("Error fetching from db", err)
+	}
+
+	p, err := getPlayerScores(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.addHoles(ctx, p, holesQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// getPlayerScores reads and closes rows.
 func getPlayerScores(rows *sql.Rows) ([]model.Score, error) {
+	defer rows.Close()
+
 	playerScores := make([]model.Score, 0)
 
 	for rows.Next() {
@@ -202,14 +575,22 @@ func getPlayerScores(rows *sql.Rows) ([]model.Score, error) {
 
 		var season int
 
-		var day string
+		var day time.Time
+
+		var courseId string
 
-		err := rows.Scan(&id, &playerId, &playerName, &points, &birdies, &eagles, &muligans, &season, &day)
+		var tee string
+
+		var handicap int
+
+		var createdAt time.Time
+
+		var updatedAt time.Time
+
+		err := rows.Scan(&id, &playerId, &playerName, &points, &birdies, &eagles, &muligans, &season, &day, &courseId, &tee, &handicap, &createdAt, &updatedAt)
 
 		if err != nil {
-			return nil, ierrors.DbError{
-				Message: "error trying to scan rows " + err.Error(),
-			}
+			return nil, ierrors.Database("error trying to scan rows", err)
 		}
 
 		playerScores = append(playerScores, model.Score{
@@ -222,8 +603,65 @@ func getPlayerScores(rows *sql.Rows) ([]model.Score, error) {
 			Muligans:   muligans,
 			Season:     season,
 			Day:        day,
+			CourseId:   courseId,
+			Tee:        tee,
+			Handicap:   handicap,
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
 		})
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading rows", err)
+	}
+
 	return playerScores, nil
 }
+
+func (r *PostgresRepository) addHoles(ctx context.Context, scores []model.Score, query string, args ...any) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	holes, err := r.queryHoles(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for i := range scores {
+		scores[i].Holes = holes[scores[i].Id]
+	}
+
+	return nil
+}
+
+// queryHoles returns the holes of the rounds by the id of the round.
+func (r *PostgresRepository) queryHoles(ctx context.Context, query string, args ...any) (map[string][]model.Hole, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ierrors.Database("Error fetching holes from db", err)
+	}
+
+	defer rows.Close()
+
+	holes := make(map[string][]model.Hole, 0)
+
+	for rows.Next() {
+		var scoreId string
+
+		var h model.Hole
+
+		err = rows.Scan(&scoreId, &h.Number, &h.Par, &h.StrokeIndex, &h.Strokes)
+		if err != nil {
+			return nil, ierrors.Database("error trying to scan rows", err)
+		}
+
+		holes[scoreId] = append(holes[scoreId], h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading holes", err)
+	}
+
+	return holes, nil
+}
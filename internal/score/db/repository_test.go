@@ -0,0 +1,38 @@
+package db_test
+
+import (
+	"database/sql"
+	"testing"
+	"tour-le-shit-go/internal/dbtest"
+	"tour-le-shit-go/internal/players"
+	playersDb "tour-le-shit-go/internal/players/db"
+	"tour-le-shit-go/internal/score"
+	"tour-le-shit-go/internal/score/conformance"
+	"tour-le-shit-go/internal/score/db"
+)
+
+func TestSqliteConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) (players.Repository, score.Repository) {
+		t.Helper()
+
+		return newRepositories(dbtest.Sqlite(t))
+	})
+}
+
+func TestPostgresConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) (players.Repository, score.Repository) {
+		t.Helper()
+
+		return newRepositories(dbtest.Postgres(t))
+	})
+}
+
+func newRepositories(database *sql.DB) (players.Repository, score.Repository) {
+	p := playersDb.NewRepository(database)
+
+	return p, db.NewRepository(database, p)
+}
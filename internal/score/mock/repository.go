@@ -1,114 +1,281 @@
 package mock
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"sort"
+	"sync"
+	"time"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
 	"tour-le-shit-go/internal/score/model"
 	"tour-le-shit-go/internal/utils"
-
-	"github.com/google/uuid"
 )
 
-const KeyDelimiter = "_"
-const BirdieMultiplier = 2
-const EagleMultiplier = 3
-const MuliganDiminisher = 3
-
+// MockedRepository keeps the scores in memory and is safe for concurrent use. Like the database
+// it reads the name of the player from the players repository, scores of deleted players are
+// hidden and deleted scores are kept until they are purged. Scores of purged players are removed
+// when the players repository tells about purges, like the mocked one does.
 type MockedRepository struct {
-	scores []model.Score
+	mu      sync.RWMutex
+	scores  []model.Score
+	deleted map[string]time.Time
+	rules   map[int]model.Rules
+	players players.Repository
 }
 
-func NewRepository(scores []model.Score) *MockedRepository {
-	return &MockedRepository{scores: scores}
+// snapshot is the content of the file written by Save. Deleted holds when each deleted score
+// was deleted.
+type snapshot struct {
+	Scores  []model.Score
+	Deleted map[string]time.Time
+	Rules   map[int]model.Rules
 }
 
-func (r *MockedRepository) GetPlayerScore(id string, season int) ([]model.Score, error) {
-	result := make([]model.Score, 0)
+// purgeNotifier a players repository which calls f with the ids of the players it purges.
+type purgeNotifier interface {
+	OnPurge(f func(ids []string))
+}
 
-	for _, s := range r.scores {
-		if s.PlayerId == id && s.Season == season {
-			result = append(result, model.Score{
-				Id:         s.Id,
-				PlayerId:   s.PlayerId,
-				PlayerName: s.PlayerName,
-				Points:     s.Points,
-				Birdies:    s.Birdies,
-				Eagles:     s.Eagles,
-				Muligans:   s.Muligans,
-				Season:     s.Season,
-				Day:        s.Day,
-			})
+func NewRepository(scores []model.Score, p players.Repository) *MockedRepository {
+	r := &MockedRepository{scores: scores, deleted: make(map[string]time.Time), rules: make(map[int]model.Rules), players: p}
+
+	if notifier, ok := p.(purgeNotifier); ok {
+		notifier.OnPurge(r.removePlayers)
+	}
+
+	return r
+}
+
+func (r *MockedRepository) GetPlayerScore(ctx context.Context, id string, season int) ([]model.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(ctx, func(s model.Score) bool {
+		return s.PlayerId == id && s.Season == season
+	})
+}
+
+func (r *MockedRepository) GetPlayerRounds(ctx context.Context, id string) ([]model.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(ctx, func(s model.Score) bool {
+		return s.PlayerId == id
+	})
+}
+
+// GetRounds returns every round of the players which are not deleted ordered by day.
+func (r *MockedRepository) GetRounds(ctx context.Context) ([]model.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(ctx, func(s model.Score) bool {
+		return true
+	})
+}
+
+func (r *MockedRepository) GetScore(ctx context.Context, id string) (*model.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result, err := r.find(ctx, func(s model.Score) bool {
+		return s.Id == id
+	})
+	if err != nil || len(result) == 0 {
+		return nil, err
+	}
+
+	return &result[0], nil
+}
+
+func (r *MockedRepository) UpdateScore(_ context.Context, score model.Score) (*model.Score, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, s := range r.scores {
+		if s.Id == score.Id {
+			r.scores[i] = score
 		}
 	}
 
-	return result, nil
+	return &score, nil
+}
+
+func (r *MockedRepository) DeleteScore(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, err := r.find(ctx, func(s model.Score) bool {
+		return s.Id == id
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(existing) == 0 {
+		return ierrors.NotFound(fmt.Sprintf("score with id %s does not exist", id))
+	}
+
+	r.deleted[id] = at
+
+	return nil
+}
+
+// GetDeletedScore returns a deleted round which has not been purged yet.
+func (r *MockedRepository) GetDeletedScore(ctx context.Context, id string) (*model.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.findDeleted(ctx, id)
+}
+
+// RestoreScore brings back a deleted round, the player of the round must not be deleted.
+func (r *MockedRepository) RestoreScore(ctx context.Context, id string) (*model.Score, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted, err := r.findDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("deleted score with id %s does not exist", id))
+	}
+
+	player, err := r.players.GetPlayerById(ctx, deleted.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if player == nil {
+		return nil, ierrors.Conflict(fmt.Sprintf("player with id %s of the score is deleted", deleted.PlayerId))
+	}
+
+	delete(r.deleted, id)
+	deleted.PlayerName = player.Name
+
+	return deleted, nil
 }
 
-func (r *MockedRepository) DeleteScore(id string) error {
-	updatedScore := make([]model.Score, 0)
+// PurgeScores removes the rounds deleted before the given time for good and returns how many
+// were removed.
+func (r *MockedRepository) PurgeScores(_ context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]model.Score, 0, len(r.scores))
 
 	for _, s := range r.scores {
-		if s.Id != id {
-			updatedScore = append(updatedScore, model.Score{
-				Id:         s.Id,
-				PlayerId:   s.PlayerId,
-				PlayerName: s.PlayerName,
-				Points:     s.Points,
-				Birdies:    s.Birdies,
-				Eagles:     s.Eagles,
-				Muligans:   s.Eagles,
-				Season:     s.Season,
-				Day:        s.Day,
-			})
+		deletedAt, ok := r.deleted[s.Id]
+		if ok && deletedAt.Before(before) {
+			delete(r.deleted, s.Id)
+
+			continue
 		}
+
+		kept = append(kept, s)
 	}
 
-	r.scores = updatedScore
+	purged := len(r.scores) - len(kept)
+	r.scores = kept
 
-	return nil
+	return purged, nil
 }
 
-func (r *MockedRepository) AddScore(input model.ScoreInput) (*model.Score, error) {
-	id := uuid.New().String()
+// removePlayers removes the scores of the purged players, deleted ones included.
+func (r *MockedRepository) removePlayers(ids []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		purged[id] = true
+	}
+
+	kept := make([]model.Score, 0, len(r.scores))
+
+	for _, s := range r.scores {
+		if purged[s.PlayerId] {
+			delete(r.deleted, s.Id)
+
+			continue
+		}
+
+		kept = append(kept, s)
+	}
+
+	r.scores = kept
+}
+
+func (r *MockedRepository) AddScore(ctx context.Context, id string, input model.ScoreInput, at time.Time) (*model.Score, error) {
+	player, err := r.players.GetPlayerById(ctx, input.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if player == nil {
+		return nil, ierrors.Invalid("playerId", "player does not exists")
+	}
+
+	if !player.ActiveIn(input.Season) {
+		return nil, ierrors.Invalid("playerId", fmt.Sprintf("player is archived from season %d", player.ArchivedFrom))
+	}
+
 	addedScore := model.Score{
 		Id:         id,
-		PlayerId:   input.PlayerId,
-		PlayerName: "Mock user",
+		PlayerId:   player.Id,
+		PlayerName: player.Name,
 		Points:     input.Points,
 		Birdies:    input.Birdies,
 		Eagles:     input.Eagles,
 		Muligans:   input.Muligans,
 		Season:     input.Season,
-		Day:        utils.GetToday(),
+		Day:        input.Day,
+		CourseId:   input.CourseId,
+		Tee:        input.Tee,
+		Handicap:   input.Handicap,
+		Holes:      input.Holes,
+		CreatedAt:  at,
+		UpdatedAt:  at,
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.scores = append(r.scores, addedScore)
 
 	return &addedScore, nil
 }
 
-func (r *MockedRepository) GetScoreboard(season int) (model.Scoreboard, error) {
-	points := make(map[string]int, 0)
-	lastPlayeds := make(map[string]string, 0)
+// GetScoreboard returns the players taking part in the season ordered by name together with their
+// rounds of the season.
+func (r *MockedRepository) GetScoreboard(ctx context.Context, season int) (model.Scoreboard, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	for _, s := range r.scores {
-		if s.Season == season {
-			key := fmt.Sprintf("%s%s%s", s.PlayerId, KeyDelimiter, s.PlayerName)
-			points[key] += s.Points + BirdieMultiplier*s.Birdies + EagleMultiplier*s.Eagles - MuliganDiminisher*s.Muligans
+	all, err := r.players.GetPlayersBySeason(ctx, season)
+	if err != nil {
+		return model.Scoreboard{}, err
+	}
+
+	sbp := make([]model.ScoreboardPlayer, 0, len(all))
 
-			if lastPlayeds[key] < s.Day {
-				lastPlayeds[key] = s.Day
-			}
+	for _, p := range all {
+		id := p.Id
+
+		rounds, err := r.find(ctx, func(s model.Score) bool {
+			return s.PlayerId == id && s.Season == season
+		})
+		if err != nil {
+			return model.Scoreboard{}, err
 		}
-	}
 
-	sbp := make([]model.ScoreboardPlayer, 0)
-	for k, v := range points {
 		sbp = append(sbp, model.ScoreboardPlayer{
-			Id:         strings.Split(k, KeyDelimiter)[0],
-			Name:       strings.Split(k, KeyDelimiter)[1],
-			Points:     v,
-			LastPlayed: lastPlayeds[k],
+			Id:     p.Id,
+			Name:   p.Name,
+			Rounds: rounds,
 		})
 	}
 
@@ -117,3 +284,103 @@ func (r *MockedRepository) GetScoreboard(season int) (model.Scoreboard, error) {
 		Season:  season,
 	}, nil
 }
+
+func (r *MockedRepository) GetRules(_ context.Context, season int) (*model.Rules, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules, ok := r.rules[season]
+	if !ok {
+		return nil, nil
+	}
+
+	return &rules, nil
+}
+
+func (r *MockedRepository) SaveRules(_ context.Context, rules model.Rules) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rules.Season] = rules
+
+	return nil
+}
+
+// Load replaces the scores and rules with the ones saved to path, nothing changes if the file is
+// missing.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var s snapshot
+	if err := utils.ReadJson(path, &s); err != nil {
+		return err
+	}
+
+	if s.Scores != nil {
+		r.scores = s.Scores
+	}
+
+	if s.Deleted != nil {
+		r.deleted = s.Deleted
+	}
+
+	if s.Rules != nil {
+		r.rules = s.Rules
+	}
+
+	return nil
+}
+
+// Save writes the scores and rules to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, snapshot{Scores: r.scores, Deleted: r.deleted, Rules: r.rules})
+}
+
+// find returns the matching scores of existing players ordered by day, deleted scores are left
+// out. The caller must hold the lock.
+func (r *MockedRepository) find(ctx context.Context, match func(s model.Score) bool) ([]model.Score, error) {
+	result := make([]model.Score, 0)
+
+	for _, s := range r.scores {
+		if _, deleted := r.deleted[s.Id]; deleted || !match(s) {
+			continue
+		}
+
+		player, err := r.players.GetPlayerById(ctx, s.PlayerId)
+		if err != nil {
+			return nil, err
+		}
+
+		if player == nil {
+			continue
+		}
+
+		s.PlayerName = player.Name
+		result = append(result, s)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Day.Before(result[j].Day)
+	})
+
+	return result, nil
+}
+
+// findDeleted returns the deleted score with the given id, the caller must hold the lock.
+func (r *MockedRepository) findDeleted(_ context.Context, id string) (*model.Score, error) {
+	if _, deleted := r.deleted[id]; !deleted {
+		return nil, nil
+	}
+
+	for _, s := range r.scores {
+		if s.Id == id {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
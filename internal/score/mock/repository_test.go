@@ -0,0 +1,73 @@
+package mock_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/players"
+	playersMock "tour-le-shit-go/internal/players/mock"
+	playersModel "tour-le-shit-go/internal/players/model"
+	"tour-le-shit-go/internal/score"
+	"tour-le-shit-go/internal/score/conformance"
+	"tour-le-shit-go/internal/score/mock"
+	"tour-le-shit-go/internal/score/model"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	conformance.Run(t, func(t *testing.T) (players.Repository, score.Repository) {
+		t.Helper()
+
+		p := playersMock.NewRepository([]playersModel.Player{})
+
+		return p, mock.NewRepository([]model.Score{}, p)
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path := filepath.Join(t.TempDir(), "scores.json")
+	p := playersMock.NewRepository([]playersModel.Player{{Id: "1", Name: "Alice"}})
+	saved := mock.NewRepository([]model.Score{}, p)
+
+	added, err := saved.AddScore(context.Background(), "1", model.ScoreInput{
+		PlayerId: "1", Points: 10, Birdies: 1, Season: 1, Day: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+		Holes: []model.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 3}},
+	}, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	rules := score.DefaultRules(1)
+	rules.BirdieMultiplier = 5
+	_ = saved.SaveRules(context.Background(), rules)
+
+	err = saved.Save(path)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	loaded := mock.NewRepository([]model.Score{}, p)
+
+	// act
+	err = loaded.Load(path)
+
+	// assert
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	got, _ := loaded.GetScore(context.Background(), added.Id)
+	if got == nil || got.Points != 10 || got.Birdies != 1 || !got.Day.Equal(added.Day) || len(got.Holes) != 1 {
+		t.Errorf("expected %v got %v", added, got)
+	}
+
+	gotRules, _ := loaded.GetRules(context.Background(), 1)
+	if gotRules == nil || gotRules.BirdieMultiplier != 5 {
+		t.Errorf("expected %v got %v", rules, gotRules)
+	}
+}
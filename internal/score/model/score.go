@@ -1,5 +1,11 @@
 package model
 
+import "time"
+
+// Score a round played by a player. Day is the day the round was played while CreatedAt
+// and UpdatedAt tell when the round was entered and last changed. Points are always gross,
+// Handicap is the playing handicap a round entered hole by hole was played off and is only
+// applied on the net scoreboard.
 type Score struct {
 	Id         string
 	PlayerId   string
@@ -9,9 +15,16 @@ type Score struct {
 	Eagles     int
 	Muligans   int
 	Season     int
-	Day        string
+	Day        time.Time
+	CourseId   string
+	Tee        string
+	Handicap   int
+	Holes      []Hole
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
+// ScoreInput a new round. Day is the day the round was played, the zero day means today.
 type ScoreInput struct {
 	PlayerId string
 	Points   int
@@ -19,6 +32,34 @@ type ScoreInput struct {
 	Eagles   int
 	Muligans int
 	Season   int
+	Day      time.Time
+	CourseId string
+	Tee      string
+	Holes    []Hole
+	Handicap int
+}
+
+// ScoreUpdate the fields to change on an existing round. Fields left nil keep their
+// current value. When holes or a handicap are given points, birdies and eagles are derived
+// from the holes again, using the handicap of the round unless another one is given.
+type ScoreUpdate struct {
+	Points   *int
+	Birdies  *int
+	Eagles   *int
+	Muligans *int
+	Day      *time.Time
+	CourseId *string
+	Tee      *string
+	Holes    *[]Hole
+	Handicap *int
+}
+
+// Hole the strokes played on a single hole together with its par and stroke index.
+type Hole struct {
+	Number      int
+	Par         int
+	StrokeIndex int
+	Strokes     int
 }
 
 type Scoreboard struct {
@@ -26,9 +67,35 @@ type Scoreboard struct {
 	Season  int
 }
 
+// ScoreboardPlayer a player on the scoreboard. Rounds are the rounds counting towards the
+// points while DroppedRounds did not count when the season only counts the best rounds.
 type ScoreboardPlayer struct {
-	Id         string
-	Name       string
-	Points     int
-	LastPlayed string
+	Id            string
+	Name          string
+	Points        int
+	NetPoints     int
+	HandicapIndex *float64
+	LastPlayed    time.Time
+	Qualified     bool
+	Rounds        []Score
+	DroppedRounds []Score
+}
+
+// Rules the house rules used to compute the total of a round for a season and to
+// break ties on the scoreboard. Birdies and eagles are the only bonuses, their multipliers and
+// caps are configurable but new kinds of bonus are not. A cap of 0 means no cap is applied,
+// BestRounds of 0 counts every round and MinRounds is the number of rounds required to qualify
+// for a position.
+type Rules struct {
+	Season            int
+	BirdieMultiplier  int
+	EagleMultiplier   int
+	MuliganDiminisher int
+	MaxBirdies        int
+	MaxEagles         int
+	MaxRoundPoints    int
+	Tiebreakers       []string
+	CountbackRounds   int
+	BestRounds        int
+	MinRounds         int
 }
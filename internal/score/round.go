@@ -0,0 +1,93 @@
+package score
+
+import (
+	"fmt"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/score/model"
+)
+
+const MaxHoles = 18
+const MinPar = 3
+const MaxPar = 6
+const StablefordBasePoints = 2
+
+// DeriveFromHoles computes gross Stableford points, birdies and eagles from a hole-by-hole round.
+func DeriveFromHoles(holes []model.Hole) (points, birdies, eagles int) {
+	for _, h := range holes {
+		switch {
+		case h.Strokes <= h.Par-2:
+			eagles++
+		case h.Strokes == h.Par-1:
+			birdies++
+		}
+	}
+
+	return StablefordPoints(holes, 0), birdies, eagles
+}
+
+// StablefordPoints computes the Stableford points of the holes on net strokes using the playing
+// handicap and the stroke index of each hole, a handicap of zero gives the gross points.
+func StablefordPoints(holes []model.Hole, handicap int) int {
+	points := 0
+
+	for _, h := range holes {
+		net := h.Strokes - strokesReceived(handicap, h.StrokeIndex)
+
+		holePoints := StablefordBasePoints + h.Par - net
+		if holePoints > 0 {
+			points += holePoints
+		}
+	}
+
+	return points
+}
+
+// ValidateHoles verifies that a hole-by-hole round is complete enough to derive a score from.
+func ValidateHoles(holes []model.Hole, handicap int) error {
+	if handicap < 0 {
+		return invalidRound("handicap", "handicap can not be negative")
+	}
+
+	if len(holes) > MaxHoles {
+		return invalidRound("holes", fmt.Sprintf("a round can not have more than %d holes", MaxHoles))
+	}
+
+	numbers := make(map[int]bool, len(holes))
+	strokeIndexes := make(map[int]bool, len(holes))
+
+	for i, h := range holes {
+		if h.Number < 1 || h.Number > MaxHoles || numbers[h.Number] {
+			return invalidRound(fmt.Sprintf("holes[%d].number", i), fmt.Sprintf("invalid or duplicate hole number %d", h.Number))
+		}
+
+		if h.StrokeIndex < 1 || h.StrokeIndex > MaxHoles || strokeIndexes[h.StrokeIndex] {
+			return invalidRound(fmt.Sprintf("holes[%d].strokeIndex", i), fmt.Sprintf("invalid or duplicate stroke index %d on hole %d", h.StrokeIndex, h.Number))
+		}
+
+		if h.Par < MinPar || h.Par > MaxPar {
+			return invalidRound(fmt.Sprintf("holes[%d].par", i), fmt.Sprintf("invalid par %d on hole %d", h.Par, h.Number))
+		}
+
+		if h.Strokes < 1 {
+			return invalidRound(fmt.Sprintf("holes[%d].strokes", i), fmt.Sprintf("invalid strokes %d on hole %d", h.Strokes, h.Number))
+		}
+
+		numbers[h.Number] = true
+		strokeIndexes[h.StrokeIndex] = true
+	}
+
+	return nil
+}
+
+func strokesReceived(handicap, strokeIndex int) int {
+	received := handicap / MaxHoles
+	if strokeIndex <= handicap%MaxHoles {
+		received++
+	}
+
+	return received
+}
+
+func invalidRound(field, message string) error {
+	return ierrors.Invalid(field, message)
+}
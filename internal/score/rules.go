@@ -0,0 +1,62 @@
+package score
+
+import (
+	"sort"
+	"tour-le-shit-go/internal/score/model"
+)
+
+const DefaultBirdieMultiplier = 2
+const DefaultEagleMultiplier = 3
+const DefaultMuliganDiminisher = 3
+
+// DefaultRules returns the rules used for a season without any stored rule set.
+func DefaultRules(season int) model.Rules {
+	return model.Rules{
+		Season:            season,
+		BirdieMultiplier:  DefaultBirdieMultiplier,
+		EagleMultiplier:   DefaultEagleMultiplier,
+		MuliganDiminisher: DefaultMuliganDiminisher,
+		MaxBirdies:        0,
+		MaxEagles:         0,
+		MaxRoundPoints:    0,
+		Tiebreakers:       []string{TiebreakLastPlayed},
+		CountbackRounds:   DefaultCountbackRounds,
+		BestRounds:        0,
+		MinRounds:         0,
+	}
+}
+
+// RoundPoints computes the total of a single round according to the rules.
+func RoundPoints(rules model.Rules, s model.Score) int {
+	birdies := capped(s.Birdies, rules.MaxBirdies)
+	eagles := capped(s.Eagles, rules.MaxEagles)
+
+	points := s.Points + rules.BirdieMultiplier*birdies + rules.EagleMultiplier*eagles - rules.MuliganDiminisher*s.Muligans
+
+	return capped(points, rules.MaxRoundPoints)
+}
+
+// CountedRounds splits the rounds into the best rounds that count towards the season
+// total and the rounds that are dropped. Rounds on the same points keep their order.
+func CountedRounds(rules model.Rules, rounds []model.Score) (counted, dropped []model.Score) {
+	if rules.BestRounds == 0 || len(rounds) <= rules.BestRounds {
+		return rounds, make([]model.Score, 0)
+	}
+
+	sorted := make([]model.Score, len(rounds))
+	copy(sorted, rounds)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return RoundPoints(rules, sorted[i]) > RoundPoints(rules, sorted[j])
+	})
+
+	return sorted[:rules.BestRounds], sorted[rules.BestRounds:]
+}
+
+func capped(value, limit int) int {
+	if limit > 0 && value > limit {
+		return limit
+	}
+
+	return value
+}
@@ -1,34 +1,71 @@
 package score
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	auditModel "tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/courses"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/players"
+	"tour-le-shit-go/internal/policy"
 	"tour-le-shit-go/internal/score/model"
+	"tour-le-shit-go/internal/seasons"
+	seasonsModel "tour-le-shit-go/internal/seasons/model"
+	"tour-le-shit-go/internal/utils"
+
+	"github.com/google/uuid"
 )
 
 type Repository interface {
-	GetPlayerScore(id string, season int) ([]model.Score, error)
-	DeleteScore(id string) error
-	AddScore(score model.ScoreInput) (*model.Score, error)
-	GetScoreboard(season int) (model.Scoreboard, error)
+	GetPlayerScore(ctx context.Context, id string, season int) ([]model.Score, error)
+	GetPlayerRounds(ctx context.Context, id string) ([]model.Score, error)
+	GetRounds(ctx context.Context) ([]model.Score, error)
+	GetScore(ctx context.Context, id string) (*model.Score, error)
+	DeleteScore(ctx context.Context, id string, at time.Time) error
+	GetDeletedScore(ctx context.Context, id string) (*model.Score, error)
+	RestoreScore(ctx context.Context, id string) (*model.Score, error)
+	PurgeScores(ctx context.Context, before time.Time) (int, error)
+	AddScore(ctx context.Context, id string, score model.ScoreInput, at time.Time) (*model.Score, error)
+	UpdateScore(ctx context.Context, score model.Score) (*model.Score, error)
+	GetScoreboard(ctx context.Context, season int) (model.Scoreboard, error)
+	GetRules(ctx context.Context, season int) (*model.Rules, error)
+	SaveRules(ctx context.Context, rules model.Rules) error
 }
 
 type Service interface {
-	GetPlayerScoreBySeason(id string, season int) ([]model.Score, error)
-	DeleteScore(id string) error
-	AddScore(score model.ScoreInput) (*model.Score, error)
-	GetScoreboard(season int) (model.Scoreboard, error)
+	GetPlayerScoreBySeason(ctx context.Context, id string, season int) ([]model.Score, error)
+	GetPlayerRounds(ctx context.Context, id string) ([]model.Score, error)
+	GetRounds(ctx context.Context) ([]model.Score, error)
+	GetScore(ctx context.Context, id string) (*model.Score, error)
+	DeleteScore(ctx context.Context, id string) error
+	RestoreScore(ctx context.Context, id string) (*model.Score, error)
+	PurgeScores(ctx context.Context, before time.Time) (int, error)
+	AddScore(ctx context.Context, score model.ScoreInput) (*model.Score, error)
+	UpdateScore(ctx context.Context, id string, update model.ScoreUpdate) (*model.Score, error)
+	GetScoreboard(ctx context.Context, season int) (model.Scoreboard, error)
+	GetRules(ctx context.Context, season int) (model.Rules, error)
+	SaveRules(ctx context.Context, rules model.Rules) (model.Rules, error)
 }
 
 type service struct {
-	r Repository
+	r       Repository
+	players players.Service
+	courses courses.Service
+	seasons seasons.Service
+	audit   audit.Service
+	clock   utils.Clock
 }
 
-func NewService(r Repository) Service {
-	return &service{r: r}
+// NewService returns a service recording every change of a round in the audit log.
+func NewService(r Repository, p players.Service, c courses.Service, se seasons.Service, a audit.Service, clock utils.Clock) Service {
+	return &service{r: r, players: p, courses: c, seasons: se, audit: a, clock: clock}
 }
 
-func (s *service) GetPlayerScoreBySeason(id string, season int) ([]model.Score, error) {
-	scores, err := s.r.GetPlayerScore(id, season)
+func (s *service) GetPlayerScoreBySeason(ctx context.Context, id string, season int) ([]model.Score, error) {
+	scores, err := s.r.GetPlayerScore(ctx, id, season)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching player with id %s from repository %w", id, err)
 	}
@@ -36,29 +73,530 @@ func (s *service) GetPlayerScoreBySeason(id string, season int) ([]model.Score,
 	return scores, nil
 }
 
-func (s *service) DeleteScore(id string) error {
-	err := s.r.DeleteScore(id)
+// GetPlayerRounds returns every round of a player across all seasons ordered by day.
+func (s *service) GetPlayerRounds(ctx context.Context, id string) ([]model.Score, error) {
+	rounds, err := s.r.GetPlayerRounds(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rounds of player with id %s from repository %w", id, err)
+	}
+
+	sort.SliceStable(rounds, func(i, j int) bool {
+		return rounds[i].Day.Before(rounds[j].Day)
+	})
+
+	return rounds, nil
+}
+
+// GetRounds returns every round of every player across all seasons ordered by day.
+func (s *service) GetRounds(ctx context.Context) ([]model.Score, error) {
+	rounds, err := s.r.GetRounds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rounds from repository %w", err)
+	}
+
+	sort.SliceStable(rounds, func(i, j int) bool {
+		return rounds[i].Day.Before(rounds[j].Day)
+	})
+
+	return rounds, nil
+}
+
+func (s *service) GetScore(ctx context.Context, id string) (*model.Score, error) {
+	score, err := s.r.GetScore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching score with id %s from repository %w", id, err)
+	}
+
+	return score, nil
+}
+
+func (s *service) DeleteScore(ctx context.Context, id string) error {
+	score, err := s.r.GetScore(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error fetching score with id %s from repository %w", id, err)
+	}
+
+	if score == nil {
+		return ierrors.NotFound(fmt.Sprintf("score with id %s does not exist", id))
+	}
+
+	err = policy.Round(ctx, score.PlayerId)
 	if err != nil {
-		return fmt.Errorf("error deleting player with id %s %w", id, err)
+		return fmt.Errorf("error authorising %w", err)
+	}
+
+	err = s.audit.Record(ctx, auditModel.EntityScore, id, auditModel.ActionDelete, *score, nil, func() error {
+		return s.r.DeleteScore(ctx, id, s.clock.Now().UTC())
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting score with id %s %w", id, err)
 	}
 
 	return nil
 }
 
-func (s *service) AddScore(scoreInput model.ScoreInput) (*model.Score, error) {
-	score, err := s.r.AddScore(scoreInput)
+// RestoreScore brings back a deleted round which has not been purged yet.
+func (s *service) RestoreScore(ctx context.Context, id string) (*model.Score, error) {
+	deleted, err := s.r.GetDeletedScore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching deleted score with id %s from repository %w", id, err)
+	}
+
+	if deleted == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("deleted score with id %s does not exist", id))
+	}
+
+	err = policy.Round(ctx, deleted.PlayerId)
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	var restored *model.Score
+
+	err = s.audit.Record(ctx, auditModel.EntityScore, id, auditModel.ActionRestore, nil, *deleted, func() (err error) {
+		restored, err = s.r.RestoreScore(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error restoring score with id %s %w", id, err)
+	}
+
+	return restored, nil
+}
+
+// PurgeScores removes the rounds deleted before the given time for good.
+func (s *service) PurgeScores(ctx context.Context, before time.Time) (int, error) {
+	err := policy.Admin(ctx, "purge rounds")
 	if err != nil {
-		return score, fmt.Errorf("error ading scoreInput to player with id %s %w", scoreInput.PlayerId, err)
+		return 0, fmt.Errorf("error authorising %w", err)
+	}
+
+	purged, err := s.r.PurgeScores(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("error purging scores from repository %w", err)
+	}
+
+	return purged, nil
+}
+
+// AddScore adds a round played on the given day, or today in the timezone of the tour when
+// no day is given. The day must be within the season and can not be in the future.
+func (s *service) AddScore(ctx context.Context, scoreInput model.ScoreInput) (*model.Score, error) {
+	err := policy.Round(ctx, scoreInput.PlayerId)
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	player, err := s.players.GetMember(ctx, scoreInput.PlayerId)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching player with id %s %w", scoreInput.PlayerId, err)
+	}
+
+	if player == nil {
+		return nil, ierrors.Invalid("playerId", "player does not exists")
+	}
+
+	season, err := s.validateSeason(ctx, scoreInput.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	if scoreInput.Day.IsZero() {
+		scoreInput.Day = s.clock.Today()
+	}
+
+	err = s.validateDay(*season, scoreInput.Day)
+	if err != nil {
+		return nil, err
+	}
+
+	if scoreInput.CourseId != "" {
+		err = s.applyCourse(ctx, scoreInput.CourseId, scoreInput.Tee, scoreInput.Holes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// totals entered with holes are derived from the holes
+	counts := []count{{"muligans", &scoreInput.Muligans}}
+	if len(scoreInput.Holes) == 0 {
+		counts = append([]count{{"points", &scoreInput.Points}, {"birdies", &scoreInput.Birdies}, {"eagles", &scoreInput.Eagles}}, counts...)
+	}
+
+	err = validateCounts(counts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scoreInput.Holes) > 0 {
+		err = ValidateHoles(scoreInput.Holes, scoreInput.Handicap)
+		if err != nil {
+			return nil, err
+		}
+
+		scoreInput.Points, scoreInput.Birdies, scoreInput.Eagles = DeriveFromHoles(scoreInput.Holes)
+	} else {
+		// a handicap is only kept for rounds entered hole by hole
+		scoreInput.Handicap = 0
+	}
+
+	id := uuid.New().String()
+	added := model.Score{
+		Id:         id,
+		PlayerId:   player.Id,
+		PlayerName: player.Name,
+		Points:     scoreInput.Points,
+		Birdies:    scoreInput.Birdies,
+		Eagles:     scoreInput.Eagles,
+		Muligans:   scoreInput.Muligans,
+		Season:     scoreInput.Season,
+		Day:        scoreInput.Day,
+		CourseId:   scoreInput.CourseId,
+		Tee:        scoreInput.Tee,
+		Handicap:   scoreInput.Handicap,
+		Holes:      scoreInput.Holes,
+	}
+
+	var score *model.Score
+
+	err = s.audit.Record(ctx, auditModel.EntityScore, id, auditModel.ActionCreate, nil, added, func() (err error) {
+		score, err = s.r.AddScore(ctx, id, scoreInput, s.clock.Now().UTC())
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error ading scoreInput to player with id %s %w", scoreInput.PlayerId, err)
 	}
 
 	return score, nil
 }
 
-func (s *service) GetScoreboard(season int) (model.Scoreboard, error) {
-	sb, err := s.r.GetScoreboard(season)
+// UpdateScore changes the given fields of an existing round while the season is still open.
+// The day of the round is kept unless it is explicitly changed. Returns nil if the round does
+// not exist.
+func (s *service) UpdateScore(ctx context.Context, id string, update model.ScoreUpdate) (*model.Score, error) {
+	score, err := s.r.GetScore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching score with id %s from repository %w", id, err)
+	}
+
+	if score == nil {
+		return nil, nil
+	}
+
+	err = policy.Round(ctx, score.PlayerId)
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	season, err := s.validateSeason(ctx, score.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpdate(update)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Day != nil {
+		err = s.validateDay(*season, *update.Day)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	before := *score
+	before.Holes = append([]model.Hole(nil), score.Holes...)
+
+	applyUpdate(score, update)
+
+	if score.CourseId != "" {
+		err = s.applyCourse(ctx, score.CourseId, score.Tee, score.Holes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if update.Handicap != nil && len(score.Holes) == 0 {
+		return nil, invalidRound("handicap", "a handicap is only used on rounds entered hole by hole")
+	}
+
+	if update.Handicap != nil {
+		score.Handicap = *update.Handicap
+	}
+
+	if update.Holes != nil || update.Handicap != nil {
+		err = ValidateHoles(score.Holes, score.Handicap)
+		if err != nil {
+			return nil, err
+		}
+
+		score.Points, score.Birdies, score.Eagles = DeriveFromHoles(score.Holes)
+	}
+
+	score.UpdatedAt = s.clock.Now().UTC()
+
+	var updated *model.Score
+
+	err = s.audit.Record(ctx, auditModel.EntityScore, id, auditModel.ActionUpdate, before, *score, func() (err error) {
+		updated, err = s.r.UpdateScore(ctx, *score)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating score with id %s %w", id, err)
+	}
+
+	return updated, nil
+}
+
+func (s *service) GetScoreboard(ctx context.Context, season int) (model.Scoreboard, error) {
+	sb, err := s.r.GetScoreboard(ctx, season)
 	if err != nil {
 		return sb, fmt.Errorf("error fetching scoreboard %w", err)
 	}
 
+	rules, err := s.GetRules(ctx, season)
+	if err != nil {
+		return sb, err
+	}
+
+	for i, p := range sb.Players {
+		var lastPlayed time.Time
+
+		for _, round := range p.Rounds {
+			if round.Day.After(lastPlayed) {
+				lastPlayed = round.Day
+			}
+		}
+
+		counted, dropped := CountedRounds(rules, p.Rounds)
+
+		points := 0
+		for _, round := range counted {
+			points += RoundPoints(rules, round)
+		}
+
+		sb.Players[i].Points = points
+		sb.Players[i].LastPlayed = lastPlayed
+		sb.Players[i].Rounds = counted
+		sb.Players[i].DroppedRounds = dropped
+		sb.Players[i].Qualified = len(p.Rounds) >= rules.MinRounds
+	}
+
 	return sb, nil
 }
+
+func (s *service) GetRules(ctx context.Context, season int) (model.Rules, error) {
+	rules, err := s.r.GetRules(ctx, season)
+	if err != nil {
+		return model.Rules{}, fmt.Errorf("error fetching rules for season %d %w", season, err)
+	}
+
+	if rules == nil {
+		return DefaultRules(season), nil
+	}
+
+	return *rules, nil
+}
+
+func (s *service) SaveRules(ctx context.Context, rules model.Rules) (model.Rules, error) {
+	err := policy.Admin(ctx, "change the rules")
+	if err != nil {
+		return model.Rules{}, fmt.Errorf("error authorising %w", err)
+	}
+
+	if rules.Season < 1 {
+		return model.Rules{}, ierrors.Invalid("season", "season must be at least 1")
+	}
+
+	err = validateRules(rules)
+	if err != nil {
+		return model.Rules{}, err
+	}
+
+	err = ValidateTiebreakers(rules)
+	if err != nil {
+		return model.Rules{}, err
+	}
+
+	err = s.r.SaveRules(ctx, rules)
+	if err != nil {
+		return model.Rules{}, fmt.Errorf("error saving rules for season %d %w", rules.Season, err)
+	}
+
+	return rules, nil
+}
+
+// applyCourse verifies the course and tee of a round and fills in par and stroke index
+// of the holes that were entered with strokes only.
+func (s *service) applyCourse(ctx context.Context, courseId, tee string, holes []model.Hole) error {
+	course, err := s.courses.GetCourse(ctx, courseId)
+	if err != nil {
+		return fmt.Errorf("error fetching course with id %s %w", courseId, err)
+	}
+
+	if course == nil {
+		return invalidRound("courseId", fmt.Sprintf("course with id %s does not exist", courseId))
+	}
+
+	if tee != "" && course.Tee(tee) == nil {
+		return invalidRound("tee", fmt.Sprintf("tee %s does not exist on course %s", tee, course.Name))
+	}
+
+	for i, h := range holes {
+		if h.Par != 0 || h.StrokeIndex != 0 {
+			continue
+		}
+
+		courseHole := course.Hole(h.Number)
+		if courseHole == nil {
+			return invalidRound(fmt.Sprintf("holes[%d].number", i), fmt.Sprintf("hole %d does not exist on course %s", h.Number, course.Name))
+		}
+
+		holes[i].Par = courseHole.Par
+		holes[i].StrokeIndex = courseHole.StrokeIndex
+	}
+
+	return nil
+}
+
+// validateSeason verifies that the season exists and is open for changes to its scores.
+func (s *service) validateSeason(ctx context.Context, id int) (*seasonsModel.Season, error) {
+	season, err := s.seasons.GetSeason(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching season %d %w", id, err)
+	}
+
+	if season == nil {
+		return nil, invalidRound("season", fmt.Sprintf("season %d does not exist", id))
+	}
+
+	if !season.IsOpen() {
+		return nil, invalidRound("season", fmt.Sprintf("season %d is closed", id))
+	}
+
+	return season, nil
+}
+
+// validateDay verifies that a round played on the day belongs to the season and was not
+// entered ahead of time.
+func (s *service) validateDay(season seasonsModel.Season, day time.Time) error {
+	if !season.Contains(day) {
+		return invalidRound("day", fmt.Sprintf("day %s is outside of season %d", utils.FormatDate(day), season.Id))
+	}
+
+	if day.After(s.clock.Today()) {
+		return invalidRound("day", fmt.Sprintf("day %s is in the future", utils.FormatDate(day)))
+	}
+
+	return nil
+}
+
+// count a total of a round, a nil value is left out of the validation.
+type count struct {
+	field string
+	value *int
+}
+
+// validateCounts reports every count with a negative value.
+func validateCounts(counts ...count) error {
+	negative := make([]ierrors.FieldError, 0)
+
+	for _, c := range counts {
+		if c.value != nil && *c.value < 0 {
+			negative = append(negative, ierrors.FieldError{Field: c.field, Message: fmt.Sprintf("%s can not be negative", c.field)})
+		}
+	}
+
+	if len(negative) > 0 {
+		return ierrors.Validation("a round can not contain negative values", negative...)
+	}
+
+	return nil
+}
+
+func validateUpdate(update model.ScoreUpdate) error {
+	err := validateCounts(
+		count{"points", update.Points},
+		count{"birdies", update.Birdies},
+		count{"eagles", update.Eagles},
+		count{"muligans", update.Muligans},
+	)
+	if err != nil {
+		return err
+	}
+
+	if update.Holes != nil && len(*update.Holes) == 0 {
+		return invalidRound("holes", "holes can not be empty, leave them out to keep the holes of the round")
+	}
+
+	return nil
+}
+
+// validateRules reports every rule with a negative value.
+func validateRules(rules model.Rules) error {
+	values := []struct {
+		field string
+		value int
+	}{
+		{"birdieMultiplier", rules.BirdieMultiplier},
+		{"eagleMultiplier", rules.EagleMultiplier},
+		{"muliganDiminisher", rules.MuliganDiminisher},
+		{"maxBirdies", rules.MaxBirdies},
+		{"maxEagles", rules.MaxEagles},
+		{"maxRoundPoints", rules.MaxRoundPoints},
+		{"bestRounds", rules.BestRounds},
+		{"minRounds", rules.MinRounds},
+	}
+
+	negative := make([]ierrors.FieldError, 0)
+
+	for _, v := range values {
+		if v.value < 0 {
+			negative = append(negative, ierrors.FieldError{Field: v.field, Message: fmt.Sprintf("%s can not be negative", v.field)})
+		}
+	}
+
+	if len(negative) > 0 {
+		return ierrors.Validation("rules can not contain negative values", negative...)
+	}
+
+	return nil
+}
+
+func applyUpdate(score *model.Score, update model.ScoreUpdate) {
+	if update.Points != nil {
+		score.Points = *update.Points
+	}
+
+	if update.Birdies != nil {
+		score.Birdies = *update.Birdies
+	}
+
+	if update.Eagles != nil {
+		score.Eagles = *update.Eagles
+	}
+
+	if update.Muligans != nil {
+		score.Muligans = *update.Muligans
+	}
+
+	if update.Day != nil {
+		score.Day = *update.Day
+	}
+
+	if update.CourseId != nil {
+		score.CourseId = *update.CourseId
+	}
+
+	if update.Tee != nil {
+		score.Tee = *update.Tee
+	}
+
+	if update.Holes != nil {
+		score.Holes = *update.Holes
+	}
+}
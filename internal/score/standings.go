@@ -0,0 +1,308 @@
+package score
+
+import (
+	"fmt"
+	"sort"
+	"tour-le-shit-go/internal/score/model"
+)
+
+const TiebreakLastPlayed = "lastplayed"
+const TiebreakBirdies = "birdies"
+const TiebreakMuligans = "muligans"
+const TiebreakBestRound = "bestround"
+const TiebreakCountback = "countback"
+const TiebreakShared = "shared"
+const TiebreakName = "name"
+const DefaultCountbackRounds = 3
+
+// Tiebreaker separates two players on the same points. Compare returns a positive number
+// when a is ahead of b, a negative number when b is ahead and 0 when they are still tied.
+type Tiebreaker interface {
+	Name() string
+	Compare(a, b model.ScoreboardPlayer) int
+}
+
+// Position the position of a player on the scoreboard. Shared is set when the player is tied
+// with another player and the season uses shared positions. Tiebreaker is the name of the
+// tiebreaker that separated the player from another player on the same points.
+type Position struct {
+	Position   int
+	Shared     bool
+	Tiebreaker string
+}
+
+// Label returns the position as shown on the scoreboard, e.g. 2 or T2 when shared.
+func (p Position) Label() string {
+	if p.Shared {
+		return fmt.Sprintf("T%d", p.Position)
+	}
+
+	return fmt.Sprintf("%d", p.Position)
+}
+
+// NewTiebreaker returns the tiebreaker with the given name configured by the rules.
+func NewTiebreaker(name string, rules model.Rules) (Tiebreaker, error) {
+	switch name {
+	case TiebreakLastPlayed:
+		return lastPlayed{}, nil
+	case TiebreakBirdies:
+		return mostBirdies{}, nil
+	case TiebreakMuligans:
+		return fewestMuligans{}, nil
+	case TiebreakBestRound:
+		return bestRound{rules: rules}, nil
+	case TiebreakCountback:
+		rounds := rules.CountbackRounds
+		if rounds == 0 {
+			rounds = DefaultCountbackRounds
+		}
+
+		return countback{rules: rules, rounds: rounds}, nil
+	}
+
+	return nil, invalidRound("tiebreakers", fmt.Sprintf("unknown tiebreaker %s", name))
+}
+
+// ValidateTiebreakers verifies that every tiebreaker is known and that shared positions
+// is the last one since no tiebreaker can be applied after it.
+func ValidateTiebreakers(rules model.Rules) error {
+	for i, name := range rules.Tiebreakers {
+		if name == TiebreakShared {
+			if i != len(rules.Tiebreakers)-1 {
+				return invalidRound("tiebreakers", "shared positions must be the last tiebreaker")
+			}
+
+			continue
+		}
+
+		_, err := NewTiebreaker(name, rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	if rules.CountbackRounds < 0 {
+		return invalidRound("countbackRounds", "countback rounds can not be negative")
+	}
+
+	return nil
+}
+
+// Rank returns the position of every player on the scoreboard by the given points with players
+// not qualified for the season placed after the qualified ones. Players on
+// the same points are separated by the tiebreakers of the rules in order. Players still tied
+// share their position when the rules end with shared positions, otherwise they are ordered by name.
+func Rank(players []model.ScoreboardPlayer, points func(model.ScoreboardPlayer) int, rules model.Rules) map[string]Position {
+	tiebreakers := make([]Tiebreaker, 0, len(rules.Tiebreakers))
+	shared := false
+
+	for _, name := range rules.Tiebreakers {
+		if name == TiebreakShared {
+			shared = true
+
+			break
+		}
+
+		t, err := NewTiebreaker(name, rules)
+		if err == nil {
+			tiebreakers = append(tiebreakers, t)
+		}
+	}
+
+	// compare returns the ordering of two players and the tiebreaker that decided it.
+	compare := func(a, b model.ScoreboardPlayer) (int, string) {
+		if a.Qualified != b.Qualified {
+			if a.Qualified {
+				return 1, ""
+			}
+
+			return -1, ""
+		}
+
+		if points(a) != points(b) {
+			return points(a) - points(b), ""
+		}
+
+		for _, t := range tiebreakers {
+			if c := t.Compare(a, b); c != 0 {
+				return c, t.Name()
+			}
+		}
+
+		if shared {
+			return 0, TiebreakShared
+		}
+
+		if a.Name != b.Name {
+			if a.Name < b.Name {
+				return 1, TiebreakName
+			}
+
+			return -1, TiebreakName
+		}
+
+		if a.Id < b.Id {
+			return 1, TiebreakName
+		}
+
+		return -1, TiebreakName
+	}
+
+	sorted := make([]model.ScoreboardPlayer, len(players))
+	copy(sorted, players)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		c, _ := compare(sorted[i], sorted[j])
+		if c == 0 {
+			return sorted[i].Name < sorted[j].Name
+		}
+
+		return c > 0
+	})
+
+	result := make(map[string]Position, len(sorted))
+
+	for i, p := range sorted {
+		position := Position{Position: i + 1, Shared: false, Tiebreaker: ""}
+
+		if i > 0 {
+			previous := result[sorted[i-1].Id]
+			c, decidedBy := compare(sorted[i-1], p)
+
+			if decidedBy != "" {
+				position.Tiebreaker = decidedBy
+
+				if previous.Tiebreaker == "" {
+					previous.Tiebreaker = decidedBy
+				}
+
+				if c == 0 {
+					position.Position = previous.Position
+					position.Shared = true
+					previous.Shared = true
+				}
+
+				result[sorted[i-1].Id] = previous
+			}
+		}
+
+		result[p.Id] = position
+	}
+
+	return result
+}
+
+// GrossPoints the points of a player on the gross scoreboard.
+func GrossPoints(p model.ScoreboardPlayer) int {
+	return p.Points
+}
+
+// NetPoints the points of a player on the net scoreboard.
+func NetPoints(p model.ScoreboardPlayer) int {
+	return p.NetPoints
+}
+
+type lastPlayed struct{}
+
+func (lastPlayed) Name() string {
+	return TiebreakLastPlayed
+}
+
+func (lastPlayed) Compare(a, b model.ScoreboardPlayer) int {
+	switch {
+	case a.LastPlayed.After(b.LastPlayed):
+		return 1
+	case a.LastPlayed.Before(b.LastPlayed):
+		return -1
+	}
+
+	return 0
+}
+
+type mostBirdies struct{}
+
+func (mostBirdies) Name() string {
+	return TiebreakBirdies
+}
+
+func (mostBirdies) Compare(a, b model.ScoreboardPlayer) int {
+	return sumRounds(a, func(s model.Score) int { return s.Birdies }) - sumRounds(b, func(s model.Score) int { return s.Birdies })
+}
+
+type fewestMuligans struct{}
+
+func (fewestMuligans) Name() string {
+	return TiebreakMuligans
+}
+
+func (fewestMuligans) Compare(a, b model.ScoreboardPlayer) int {
+	return sumRounds(b, func(s model.Score) int { return s.Muligans }) - sumRounds(a, func(s model.Score) int { return s.Muligans })
+}
+
+type bestRound struct {
+	rules model.Rules
+}
+
+func (bestRound) Name() string {
+	return TiebreakBestRound
+}
+
+func (t bestRound) Compare(a, b model.ScoreboardPlayer) int {
+	return t.best(a) - t.best(b)
+}
+
+func (t bestRound) best(p model.ScoreboardPlayer) int {
+	best := 0
+
+	for i, r := range p.Rounds {
+		points := RoundPoints(t.rules, r)
+		if i == 0 || points > best {
+			best = points
+		}
+	}
+
+	return best
+}
+
+// countback compares the total of the last rounds played.
+type countback struct {
+	rules  model.Rules
+	rounds int
+}
+
+func (countback) Name() string {
+	return TiebreakCountback
+}
+
+func (t countback) Compare(a, b model.ScoreboardPlayer) int {
+	return t.total(a) - t.total(b)
+}
+
+func (t countback) total(p model.ScoreboardPlayer) int {
+	rounds := make([]model.Score, len(p.Rounds))
+	copy(rounds, p.Rounds)
+
+	sort.SliceStable(rounds, func(i, j int) bool {
+		return rounds[i].Day.After(rounds[j].Day)
+	})
+
+	if len(rounds) > t.rounds {
+		rounds = rounds[:t.rounds]
+	}
+
+	total := 0
+	for _, r := range rounds {
+		total += RoundPoints(t.rules, r)
+	}
+
+	return total
+}
+
+func sumRounds(p model.ScoreboardPlayer, value func(model.Score) int) int {
+	total := 0
+	for _, r := range p.Rounds {
+		total += value(r)
+	}
+
+	return total
+}
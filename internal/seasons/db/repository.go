@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/seasons/model"
+)
+
+const GetSeasonByIdQuery = "SELECT id, name, start_date, end_date, status FROM season WHERE id = $1"
+const GetSeasonsQuery = "SELECT id, name, start_date, end_date, status FROM season ORDER BY id;"
+const GetCountSeasonsByIdQuery = "SELECT count(*) FROM season WHERE id = $1"
+const InsertSeasonQuery = "INSERT INTO season (id, name, start_date, end_date, status) VALUES ($1, $2, $3, $4, $5);"
+const UpdateSeasonQuery = "UPDATE season SET name = $2, start_date = $3, end_date = $4, status = $5 WHERE id = $1;"
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) GetSeasonById(ctx context.Context, id int) (*model.Season, error) {
+	var s model.Season
+
+	err := r.db.QueryRowContext(ctx, GetSeasonByIdQuery, id).Scan(&s.Id, &s.Name, &s.StartDate, &s.EndDate, &s.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ierrors.Database("error scanning season", err)
+	}
+
+	s = inUTC(s)
+
+	return &s, nil
+}
+
+func (r *PostgresRepository) GetSeasons(ctx context.Context) ([]model.Season, error) {
+	rows, err := r.db.QueryContext(ctx, GetSeasonsQuery)
+	if err != nil {
+		return nil, ierrors.Database("error fetching seasons", err)
+	}
+
+	defer rows.Close()
+
+	seasons := make([]model.Season, 0)
+
+	for rows.Next() {
+		var s model.Season
+
+		err = rows.Scan(&s.Id, &s.Name, &s.StartDate, &s.EndDate, &s.Status)
+		if err != nil {
+			return nil, ierrors.Database("error scanning rows", err)
+		}
+
+		seasons = append(seasons, inUTC(s))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ierrors.Database("error reading seasons", err)
+	}
+
+	return seasons, nil
+}
+
+func (r *PostgresRepository) CreateSeason(ctx context.Context, season model.Season) (*model.Season, error) {
+	count, err := r.countSeasons(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 0 {
+		return nil, ierrors.Conflict(fmt.Sprintf("season %d already exists.", season.Id))
+	}
+
+	_, err = r.db.ExecContext(ctx, InsertSeasonQuery, season.Id, season.Name, season.StartDate, season.EndDate, season.Status)
+	if err != nil {
+		return nil, ierrors.Database("error executing insert season query", err)
+	}
+
+	return &season, nil
+}
+
+func (r *PostgresRepository) UpdateSeason(ctx context.Context, season model.Season) (*model.Season, error) {
+	count, err := r.countSeasons(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, ierrors.NotFound(fmt.Sprintf("season %d does not exist", season.Id))
+	}
+
+	_, err = r.db.ExecContext(ctx, UpdateSeasonQuery, season.Id, season.Name, season.StartDate, season.EndDate, season.Status)
+	if err != nil {
+		return nil, ierrors.Database("error executing update season query", err)
+	}
+
+	return &season, nil
+}
+
+func (r *PostgresRepository) countSeasons(ctx context.Context, id int) (int, error) {
+	var count int
+
+	err := r.db.QueryRowContext(ctx, GetCountSeasonsByIdQuery, id).Scan(&count)
+	if err != nil {
+		return 0, ierrors.Database("error running season count query", err)
+	}
+
+	return count, nil
+}
+
+// inUTC returns the season with its dates in UTC whatever location the driver read them in.
+func inUTC(s model.Season) model.Season {
+	s.StartDate = s.StartDate.UTC()
+	s.EndDate = s.EndDate.UTC()
+
+	return s
+}
@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	"tour-le-shit-go/internal/dbtest"
+	"tour-le-shit-go/internal/seasons/db"
+	"tour-le-shit-go/internal/seasons/model"
+)
+
+func TestSqliteRepository(t *testing.T) {
+	t.Parallel()
+
+	run(t, dbtest.Sqlite)
+}
+
+func TestPostgresRepository(t *testing.T) {
+	t.Parallel()
+
+	run(t, dbtest.Postgres)
+}
+
+func run(t *testing.T, open func(t *testing.T) *sql.DB) {
+	t.Helper()
+
+	season := model.Season{Id: 1, Name: "2022", StartDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC), Status: model.StatusOpen}
+
+	t.Run("updated season is returned", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := db.NewRepository(open(t))
+
+		_, err := r.CreateSeason(context.Background(), season)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		closed := season
+		closed.Status = model.StatusClosed
+
+		_, err = r.UpdateSeason(context.Background(), closed)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		// act
+		result, err := r.GetSeasonById(context.Background(), season.Id)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if result == nil || *result != closed {
+			t.Errorf("expected %v got %v", closed, result)
+		}
+	})
+	t.Run("unknown season returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		r := db.NewRepository(open(t))
+
+		// act
+		result, err := r.GetSeasonById(context.Background(), 2)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		if result != nil {
+			t.Errorf("expected %v got %v", nil, result)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/seasons/model"
+	"tour-le-shit-go/internal/utils"
+)
+
+// MockedRepository keeps the seasons in memory and is safe for concurrent use.
+type MockedRepository struct {
+	mu      sync.RWMutex
+	seasons []model.Season
+}
+
+func NewRepository(seasons []model.Season) *MockedRepository {
+	return &MockedRepository{seasons: seasons}
+}
+
+func (r *MockedRepository) GetSeasonById(_ context.Context, id int) (*model.Season, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.seasons {
+		if s.Id == id {
+			season := s
+
+			return &season, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockedRepository) GetSeasons(_ context.Context) ([]model.Season, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.Season, len(r.seasons))
+	copy(result, r.seasons)
+
+	return result, nil
+}
+
+func (r *MockedRepository) CreateSeason(_ context.Context, season model.Season) (*model.Season, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.seasons {
+		if s.Id == season.Id {
+			return nil, ierrors.Conflict(fmt.Sprintf("season %d already exists.", season.Id))
+		}
+	}
+
+	r.seasons = append(r.seasons, season)
+
+	return &season, nil
+}
+
+func (r *MockedRepository) UpdateSeason(_ context.Context, season model.Season) (*model.Season, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, s := range r.seasons {
+		if s.Id == season.Id {
+			r.seasons[i] = season
+
+			return &season, nil
+		}
+	}
+
+	return nil, ierrors.NotFound(fmt.Sprintf("season %d does not exist", season.Id))
+}
+
+// Load replaces the seasons with the ones saved to path, nothing changes if the file is missing.
+func (r *MockedRepository) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var seasons []model.Season
+	if err := utils.ReadJson(path, &seasons); err != nil {
+		return err
+	}
+
+	if seasons != nil {
+		r.seasons = seasons
+	}
+
+	return nil
+}
+
+// Save writes the seasons to path as JSON.
+func (r *MockedRepository) Save(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return utils.WriteJson(path, r.seasons)
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const StatusOpen = "open"
+const StatusClosed = "closed"
+
+// Season a season of the tour. StartDate and EndDate are midnight UTC of the first and last day
+// of the season.
+type Season struct {
+	Id        int
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string
+}
+
+// IsOpen returns true if scores can be added to the season.
+func (s Season) IsOpen() bool {
+	return s.Status == StatusOpen
+}
+
+// Contains returns true if the day is within the date range of the season.
+func (s Season) Contains(day time.Time) bool {
+	return !day.Before(s.StartDate) && !day.After(s.EndDate)
+}
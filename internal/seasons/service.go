@@ -0,0 +1,206 @@
+package seasons
+
+import (
+	"context"
+	"fmt"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/policy"
+	"tour-le-shit-go/internal/seasons/model"
+	"tour-le-shit-go/internal/utils"
+)
+
+type Repository interface {
+	GetSeasonById(ctx context.Context, id int) (*model.Season, error)
+	GetSeasons(ctx context.Context) ([]model.Season, error)
+	CreateSeason(ctx context.Context, season model.Season) (*model.Season, error)
+	UpdateSeason(ctx context.Context, season model.Season) (*model.Season, error)
+}
+
+type Service interface {
+	GetSeason(ctx context.Context, id int) (*model.Season, error)
+	GetSeasons(ctx context.Context) ([]model.Season, error)
+	GetCurrentSeason(ctx context.Context) (*model.Season, error)
+	CreateSeason(ctx context.Context, season model.Season) (*model.Season, error)
+	UpdateSeason(ctx context.Context, season model.Season) (*model.Season, error)
+	CloseSeason(ctx context.Context, id int) (*model.Season, error)
+}
+
+type service struct {
+	r     Repository
+	clock utils.Clock
+}
+
+func NewService(r Repository, clock utils.Clock) Service {
+	return &service{r: r, clock: clock}
+}
+
+func (s *service) GetSeason(ctx context.Context, id int) (*model.Season, error) {
+	season, err := s.r.GetSeasonById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching season %d from repository %w", id, err)
+	}
+
+	return season, nil
+}
+
+func (s *service) GetSeasons(ctx context.Context) ([]model.Season, error) {
+	all, err := s.r.GetSeasons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching seasons from repository %w", err)
+	}
+
+	return all, nil
+}
+
+// GetCurrentSeason returns the open season that today falls within or nil if there is none.
+func (s *service) GetCurrentSeason(ctx context.Context) (*model.Season, error) {
+	all, err := s.GetSeasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.clock.Today()
+
+	for _, season := range all {
+		if season.IsOpen() && season.Contains(today) {
+			current := season
+
+			return &current, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *service) CreateSeason(ctx context.Context, season model.Season) (*model.Season, error) {
+	err := policy.Admin(ctx, "manage seasons")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	if season.Status == "" {
+		season.Status = model.StatusOpen
+	}
+
+	if season.Status == model.StatusClosed {
+		return nil, invalidSeason("status", "a season is closed with POST /seasons/{id}/close once it has been played")
+	}
+
+	err = validate(season)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.r.CreateSeason(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("error creating season from repository %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateSeason changes the name and dates of a season. The status is kept, seasons are only
+// closed by CloseSeason and a closed season is never opened again since its final standings
+// must not change.
+func (s *service) UpdateSeason(ctx context.Context, season model.Season) (*model.Season, error) {
+	err := policy.Admin(ctx, "manage seasons")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	current, err := s.GetSeason(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("season %d does not exist", season.Id))
+	}
+
+	if season.Status == "" {
+		season.Status = current.Status
+	}
+
+	switch {
+	case season.Status == current.Status:
+	case current.Status == model.StatusClosed:
+		return nil, ierrors.Conflict(fmt.Sprintf("season %d is closed and its final standings can not change", season.Id))
+	default:
+		return nil, invalidSeason("status", "a season is closed with POST /seasons/{id}/close")
+	}
+
+	err = validate(season)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.r.UpdateSeason(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("error updating season from repository %w", err)
+	}
+
+	return updated, nil
+}
+
+// CloseSeason closes an open season for new scores, it is used by the hall of fame once the
+// final standings of the season are saved.
+func (s *service) CloseSeason(ctx context.Context, id int) (*model.Season, error) {
+	err := policy.Admin(ctx, "manage seasons")
+	if err != nil {
+		return nil, fmt.Errorf("error authorising %w", err)
+	}
+
+	season, err := s.GetSeason(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if season == nil {
+		return nil, ierrors.NotFound(fmt.Sprintf("season %d does not exist", id))
+	}
+
+	if !season.IsOpen() {
+		return nil, ierrors.Conflict(fmt.Sprintf("season %d is already closed", id))
+	}
+
+	season.Status = model.StatusClosed
+
+	closed, err := s.r.UpdateSeason(ctx, *season)
+	if err != nil {
+		return nil, fmt.Errorf("error closing season from repository %w", err)
+	}
+
+	return closed, nil
+}
+
+func validate(season model.Season) error {
+	if season.Id < 1 {
+		return invalidSeason("id", fmt.Sprintf("invalid season id %d", season.Id))
+	}
+
+	if season.Name == "" {
+		return invalidSeason("name", "season name can not be empty")
+	}
+
+	if season.StartDate.IsZero() {
+		return invalidSeason("startDate", "start date is required")
+	}
+
+	if season.EndDate.IsZero() {
+		return invalidSeason("endDate", "end date is required")
+	}
+
+	if season.EndDate.Before(season.StartDate) {
+		return invalidSeason("endDate", "end date can not be before start date")
+	}
+
+	if season.Status != model.StatusOpen && season.Status != model.StatusClosed {
+		return invalidSeason("status", fmt.Sprintf("invalid status %s, expected %s or %s", season.Status, model.StatusOpen, model.StatusClosed))
+	}
+
+	return nil
+}
+
+func invalidSeason(field, message string) error {
+	return ierrors.Invalid(field, message)
+}
@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"tour-le-shit-go/internal/migrations"
+
+	_ "modernc.org/sqlite"
+)
+
+// Open opens the SQLite database stored in the file at path and applies the pending
+// migrations. Writes are serialized over a single connection since SQLite locks the whole
+// file while writing.
+func Open(path string) (*sql.DB, error) {
+	database, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite database %s %w", path, err)
+	}
+
+	database.SetMaxOpenConns(1)
+
+	runner, err := migrations.NewRunner(database, migrations.Sqlite)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = runner.Up()
+	if err != nil {
+		_ = database.Close()
+
+		return nil, fmt.Errorf("error migrating sqlite database %s %w", path, err)
+	}
+
+	return database, nil
+}
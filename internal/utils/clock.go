@@ -0,0 +1,36 @@
+package utils
+
+import "time"
+
+// Clock tells the current day in the timezone of the tour.
+type Clock interface {
+	Today() time.Time
+	Now() time.Time
+}
+
+type clock struct {
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewClock returns a clock telling the current day in the given location.
+func NewClock(location *time.Location) Clock {
+	return NewClockAt(location, time.Now)
+}
+
+// NewClockAt returns a clock reading the time from now, which allows the day to be pinned.
+func NewClockAt(location *time.Location, now func() time.Time) Clock {
+	return clock{location: location, now: now}
+}
+
+// Today returns midnight UTC of the current calendar day in the location of the clock.
+func (c clock) Today() time.Time {
+	year, month, day := c.now().In(c.location).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Now returns the current time.
+func (c clock) Now() time.Time {
+	return c.now()
+}
@@ -2,6 +2,25 @@ package utils
 
 import "time"
 
-func GetToday() string {
-	return time.Now().Format("2006-01-02")
+const DateLayout = "2006-01-02"
+
+// IsDate verifies that the value is a date formatted as yyyy-mm-dd.
+func IsDate(value string) bool {
+	_, err := time.Parse(DateLayout, value)
+
+	return err == nil
+}
+
+// ParseDate parses a date formatted as yyyy-mm-dd to midnight UTC of that day.
+func ParseDate(value string) (time.Time, error) {
+	return time.Parse(DateLayout, value)
+}
+
+// FormatDate formats the day as yyyy-mm-dd, the zero time is formatted as an empty string.
+func FormatDate(day time.Time) string {
+	if day.IsZero() {
+		return ""
+	}
+
+	return day.Format(DateLayout)
 }
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ReadJson decodes the JSON file at path into v. A missing file leaves v untouched.
+func ReadJson(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("could not decode %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// WriteJson encodes v as JSON to the file at path. The file is written next to its destination
+// and then renamed so a crash never leaves a half written file behind.
+func WriteJson(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode %s: %w", path, err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create directory of %s: %w", path, err)
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("could not write %s: %w", tmp, err)
+	}
+
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("could not replace %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -1,29 +1,83 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	auditDb "tour-le-shit-go/internal/audit/db"
+	auditMock "tour-le-shit-go/internal/audit/mock"
+	auditModel "tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/auth"
+	authDb "tour-le-shit-go/internal/auth/db"
+	authMock "tour-le-shit-go/internal/auth/mock"
+	authModel "tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/courses"
+	coursesDb "tour-le-shit-go/internal/courses/db"
+	coursesMock "tour-le-shit-go/internal/courses/mock"
+	coursesModel "tour-le-shit-go/internal/courses/model"
 	"tour-le-shit-go/internal/env"
+	"tour-le-shit-go/internal/halloffame"
+	hallOfFameDb "tour-le-shit-go/internal/halloffame/db"
+	hallOfFameMock "tour-le-shit-go/internal/halloffame/mock"
+	hallOfFameModel "tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/handicap"
+	"tour-le-shit-go/internal/migrations"
 	"tour-le-shit-go/internal/players"
 	playersDb "tour-le-shit-go/internal/players/db"
 	playersMock "tour-le-shit-go/internal/players/mock"
 	playersModel "tour-le-shit-go/internal/players/model"
+	"tour-le-shit-go/internal/purge"
+	auditRoute "tour-le-shit-go/internal/routes/audit"
+	authRoute "tour-le-shit-go/internal/routes/auth"
+	coursesRoute "tour-le-shit-go/internal/routes/courses"
+	hallOfFameRoute "tour-le-shit-go/internal/routes/halloffame"
+	handicapRoute "tour-le-shit-go/internal/routes/handicap"
 	"tour-le-shit-go/internal/routes/members"
+	"tour-le-shit-go/internal/routes/rules"
 	"tour-le-shit-go/internal/routes/scoreboard"
 	"tour-le-shit-go/internal/routes/scores"
+	seasonsRoute "tour-le-shit-go/internal/routes/seasons"
 	"tour-le-shit-go/internal/score"
 	scoreDb "tour-le-shit-go/internal/score/db"
 	scoreMock "tour-le-shit-go/internal/score/mock"
 	scoreModel "tour-le-shit-go/internal/score/model"
+	"tour-le-shit-go/internal/seasons"
+	seasonsDb "tour-le-shit-go/internal/seasons/db"
+	seasonsMock "tour-le-shit-go/internal/seasons/mock"
+	seasonsModel "tour-le-shit-go/internal/seasons/model"
+	"tour-le-shit-go/internal/sqlite"
+	"tour-le-shit-go/internal/utils"
 	"tour-le-shit-go/pkg/server"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	_ "time/tzdata"
 )
 
 const MockMode = "MOCK"
 const PsqlMode = "PSQL"
+const SqliteMode = "SQLITE"
+const MigrateCommand = "migrate"
+
+// MinAuthSecretLength the session tokens are signed with HMAC-SHA256 which wants a key of at least
+// 32 bytes.
+const MinAuthSecretLength = 32
+
+// persistent is implemented by the MOCK repositories which can be saved to a JSON file.
+type persistent interface {
+	Load(path string) error
+	Save(path string) error
+}
 
 func main() {
 	err := godotenv.Load(".env", ".env.default")
@@ -33,6 +87,62 @@ func main() {
 
 	appEnv := env.GetAppEnv()
 
+	if len(os.Args) > 1 && os.Args[1] == MigrateCommand {
+		migrate(appEnv, os.Args[2:])
+
+		return
+	}
+
+	if usesPsql(appEnv) {
+		migrate(appEnv, []string{"up"})
+	}
+
+	location, err := time.LoadLocation(appEnv.Timezone)
+	if err != nil {
+		panic(fmt.Sprintf("invalid timezone %s", appEnv.Timezone))
+	}
+
+	retentionDays, err := strconv.Atoi(appEnv.RetentionDays)
+	if err != nil || retentionDays < 1 {
+		panic(fmt.Sprintf("invalid retention days %s, expected at least one day", appEnv.RetentionDays))
+	}
+
+	var sqliteDatabase *sql.DB
+
+	if usesSqlite(appEnv) {
+		sqliteDatabase, err = sqlite.Open(appEnv.SqlitePath)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	stores := make(map[string]persistent)
+
+	var auditRepository audit.Repository
+
+	switch appEnv.AuditMode {
+	case PsqlMode:
+		database, err := sql.Open("postgres", fmt.Sprintf("user=%s dbname=%s password=%s sslmode=disable", appEnv.Db.Username, appEnv.Db.Name, appEnv.Db.Password))
+		if err != nil {
+			panic(err)
+		}
+
+		auditRepository = auditDb.NewRepository(database)
+	case SqliteMode:
+		auditRepository = auditDb.NewRepository(sqliteDatabase)
+	case MockMode:
+		mock := auditMock.NewRepository([]auditModel.Entry{})
+		stores["audit"] = mock
+		auditRepository = mock
+	default:
+		panic(fmt.Sprintf("invalid audit mode %s", appEnv.AuditMode))
+	}
+
+	auditService := audit.NewService(auditRepository, utils.NewClock(location), map[string]audit.Representation{
+		auditModel.EntityPlayer: members.AuditRepresentation(),
+		auditModel.EntityScore:  scores.AuditRepresentation(),
+	})
+
 	var playersRepository players.Repository
 
 	switch appEnv.MembersMode {
@@ -43,12 +153,60 @@ func main() {
 		}
 
 		playersRepository = playersDb.NewRepository(database)
+	case SqliteMode:
+		playersRepository = playersDb.NewRepository(sqliteDatabase)
 	case MockMode:
-		playersRepository = playersMock.NewRepository([]playersModel.Player{})
+		mock := playersMock.NewRepository([]playersModel.Player{})
+		stores["members"] = mock
+		playersRepository = mock
 	default:
 		panic(fmt.Sprintf("invalid members mode %s", appEnv.MembersMode))
 	}
 
+	var coursesRepository courses.Repository
+
+	switch appEnv.CoursesMode {
+	case PsqlMode:
+		database, err := sql.Open("postgres", fmt.Sprintf("user=%s dbname=%s password=%s sslmode=disable", appEnv.Db.Username, appEnv.Db.Name, appEnv.Db.Password))
+		if err != nil {
+			panic(err)
+		}
+
+		coursesRepository = coursesDb.NewRepository(database)
+	case SqliteMode:
+		coursesRepository = coursesDb.NewRepository(sqliteDatabase)
+	case MockMode:
+		mock := coursesMock.NewRepository([]coursesModel.Course{})
+		stores["courses"] = mock
+		coursesRepository = mock
+	default:
+		panic(fmt.Sprintf("invalid courses mode %s", appEnv.CoursesMode))
+	}
+
+	coursesService := courses.NewService(coursesRepository)
+
+	var seasonsRepository seasons.Repository
+
+	switch appEnv.SeasonsMode {
+	case PsqlMode:
+		database, err := sql.Open("postgres", fmt.Sprintf("user=%s dbname=%s password=%s sslmode=disable", appEnv.Db.Username, appEnv.Db.Name, appEnv.Db.Password))
+		if err != nil {
+			panic(err)
+		}
+
+		seasonsRepository = seasonsDb.NewRepository(database)
+	case SqliteMode:
+		seasonsRepository = seasonsDb.NewRepository(sqliteDatabase)
+	case MockMode:
+		mock := seasonsMock.NewRepository([]seasonsModel.Season{})
+		stores["seasons"] = mock
+		seasonsRepository = mock
+	default:
+		panic(fmt.Sprintf("invalid seasons mode %s", appEnv.SeasonsMode))
+	}
+
+	seasonsService := seasons.NewService(seasonsRepository, utils.NewClock(location))
+
 	var scoreRepository score.Repository
 
 	switch appEnv.ScoreMode {
@@ -59,26 +217,195 @@ func main() {
 		}
 
 		scoreRepository = scoreDb.NewRepository(database, playersRepository)
+	case SqliteMode:
+		scoreRepository = scoreDb.NewRepository(sqliteDatabase, playersRepository)
 	case MockMode:
-		scoreRepository = scoreMock.NewRepository([]scoreModel.Score{})
+		mock := scoreMock.NewRepository([]scoreModel.Score{}, playersRepository)
+		stores["scores"] = mock
+		scoreRepository = mock
 	default:
 		panic(fmt.Sprintf("invalid score mode %s", appEnv.ScoreMode))
 	}
 
-	scoreService := score.NewService(scoreRepository)
+	playersService := players.NewService(playersRepository, auditService, utils.NewClock(location))
+	scoreService := score.NewService(scoreRepository, playersService, coursesService, seasonsService, auditService, utils.NewClock(location))
+
+	var hallOfFameRepository halloffame.Repository
+
+	switch appEnv.HallOfFameMode {
+	case PsqlMode:
+		database, err := sql.Open("postgres", fmt.Sprintf("user=%s dbname=%s password=%s sslmode=disable", appEnv.Db.Username, appEnv.Db.Name, appEnv.Db.Password))
+		if err != nil {
+			panic(err)
+		}
+
+		hallOfFameRepository = hallOfFameDb.NewRepository(database)
+	case SqliteMode:
+		hallOfFameRepository = hallOfFameDb.NewRepository(sqliteDatabase)
+	case MockMode:
+		mock := hallOfFameMock.NewRepository([]hallOfFameModel.FinalStandings{})
+		stores["halloffame"] = mock
+		hallOfFameRepository = mock
+	default:
+		panic(fmt.Sprintf("invalid hall of fame mode %s", appEnv.HallOfFameMode))
+	}
+
+	hallOfFameService := halloffame.NewService(hallOfFameRepository, seasonsService, scoreService, utils.NewClock(location))
 
-	playersService := players.NewService(playersRepository)
+	if len(appEnv.AuthSecret) < MinAuthSecretLength {
+		panic(fmt.Sprintf("AUTH_SECRET must be at least %d characters", MinAuthSecretLength))
+	}
+
+	var authRepository auth.Repository
+
+	switch appEnv.AuthMode {
+	case PsqlMode:
+		database, err := sql.Open("postgres", fmt.Sprintf("user=%s dbname=%s password=%s sslmode=disable", appEnv.Db.Username, appEnv.Db.Name, appEnv.Db.Password))
+		if err != nil {
+			panic(err)
+		}
+
+		authRepository = authDb.NewRepository(database)
+	case SqliteMode:
+		authRepository = authDb.NewRepository(sqliteDatabase)
+	case MockMode:
+		mock := authMock.NewRepository([]authModel.User{}, playersRepository)
+		stores["auth"] = mock
+		authRepository = mock
+	default:
+		panic(fmt.Sprintf("invalid auth mode %s", appEnv.AuthMode))
+	}
+
+	authService := auth.NewService(authRepository, playersService, []byte(appEnv.AuthSecret), utils.NewClock(location))
+
+	handicapService := handicap.NewService(playersService, scoreService, coursesService)
 
 	config := server.Config{
+		Auth:            authService,
+		AuditRoute:      auditRoute.NewAuditRoute(auditService),
+		AuthRoute:       authRoute.NewAuthRoute(authService),
+		CoursesRoute:    coursesRoute.NewCourseRoute(coursesService),
+		HallOfFameRoute: hallOfFameRoute.NewHallOfFameRoute(hallOfFameService),
+		HandicapRoute:   handicapRoute.NewHandicapRoute(handicapService),
 		ScoresRoute:     scores.NewScoresRoute(scoreService),
-		ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService),
+		SeasonsRoute:    seasonsRoute.NewSeasonRoute(seasonsService),
+		ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicapService),
 		Port:            appEnv.Port,
 		MembersRoute:    members.NewMemberRoute(playersService),
+		RulesRoute:      rules.NewRulesRoute(scoreService),
+	}
+
+	if appEnv.MockDataPath != "" {
+		for name, store := range stores {
+			if err = store.Load(filepath.Join(appEnv.MockDataPath, name+".json")); err != nil {
+				panic(err)
+			}
+		}
 	}
 
 	srv := server.New(config)
 
-	if err = srv.ListenAndServe(); err != nil {
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	purgeJob := purge.NewJob(playersService, scoreService, time.Duration(retentionDays)*24*time.Hour, utils.NewClock(location))
+
+	go purgeJob.Schedule(purgeCtx, purge.Interval)
+
+	serve(srv)
+	stopPurge()
+
+	if appEnv.MockDataPath != "" {
+		for name, store := range stores {
+			if err = store.Save(filepath.Join(appEnv.MockDataPath, name+".json")); err != nil {
+				log.Printf("could not save %s: %v", name, err)
+			}
+		}
+	}
+}
+
+// serve runs the server until SIGINT or SIGTERM is received and then waits for the requests in
+// flight to finish.
+func serve(srv *http.Server) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		panic(err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.Timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("could not shut down the server: %v", err)
+	}
+}
+
+// migrate runs the migrate subcommand, "up" applies every pending migration while "down"
+// reverts the latest applied migration.
+func migrate(appEnv env.AppEnv, args []string) {
+	database, err := sql.Open("postgres", fmt.Sprintf("user=%s dbname=%s password=%s sslmode=disable", appEnv.Db.Username, appEnv.Db.Name, appEnv.Db.Password))
+	if err != nil {
+		panic(err)
+	}
+
+	defer database.Close()
+
+	runner, err := migrations.NewRunner(database, migrations.Postgres)
+	if err != nil {
 		panic(err)
 	}
+
+	direction := "up"
+	if len(args) > 0 {
+		direction = args[0]
+	}
+
+	switch direction {
+	case "up":
+		applied, err := runner.Up()
+		for _, m := range applied {
+			log.Printf("applied migration %d_%s", m.Version, m.Name)
+		}
+
+		if err != nil {
+			panic(err)
+		}
+	case "down":
+		reverted, err := runner.Down()
+		if err != nil {
+			panic(err)
+		}
+
+		if reverted != nil {
+			log.Printf("reverted migration %d_%s", reverted.Version, reverted.Name)
+		}
+	default:
+		panic(fmt.Sprintf("invalid migrate direction %s, expected up or down", direction))
+	}
+}
+
+func usesPsql(appEnv env.AppEnv) bool {
+	return usesMode(appEnv, PsqlMode)
+}
+
+func usesSqlite(appEnv env.AppEnv) bool {
+	return usesMode(appEnv, SqliteMode)
+}
+
+func usesMode(appEnv env.AppEnv, expected string) bool {
+	for _, mode := range []string{appEnv.AuditMode, appEnv.AuthMode, appEnv.CoursesMode, appEnv.HallOfFameMode, appEnv.MembersMode, appEnv.ScoreMode, appEnv.SeasonsMode} {
+		if mode == expected {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"tour-le-shit-go/internal/auth"
+	"tour-le-shit-go/internal/ierrors"
+	"tour-le-shit-go/internal/policy"
+
+	"github.com/gorilla/mux"
+)
+
+const bearerPrefix = "Bearer "
+
+// authenticate requires a session or API token on every route except the ones open to anonymous
+// requests, the user of the token is added to the context of the request.
+func authenticate(s auth.Service) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return rootHandler(func(w http.ResponseWriter, r *http.Request) error {
+			token, ok := bearerToken(r)
+			if !ok {
+				if allowsAnonymous(r) {
+					next.ServeHTTP(w, r)
+
+					return nil
+				}
+
+				w.Header().Set("WWW-Authenticate", `Bearer realm="tour-le-shit"`)
+
+				return ierrors.Unauthorized("missing bearer token in the Authorization header")
+			}
+
+			user, err := s.Authenticate(r.Context(), token)
+			if ierrors.KindOf(err) == ierrors.KindUnauthorized && allowsAnonymous(r) {
+				next.ServeHTTP(w, r)
+
+				return nil
+			}
+
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="tour-le-shit", error="invalid_token"`)
+
+				return err
+			}
+
+			next.ServeHTTP(w, r.WithContext(policy.WithUser(r.Context(), *user)))
+
+			return nil
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	return header[len(bearerPrefix):], true
+}
+
+// allowsAnonymous tells if the route of r can be used without logging in, which is needed to log
+// in and to create the first user.
+func allowsAnonymous(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+
+	switch template {
+	case "/auth/login", "/auth/users":
+		return true
+	}
+
+	return false
+}
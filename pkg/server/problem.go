@@ -0,0 +1,75 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"tour-le-shit-go/internal/ierrors"
+)
+
+// ProblemContentType is the content type of a problem response as defined by RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+const internalErrorDetail = "server error, please contact support"
+
+// Problem is the body of an error response as defined by RFC 7807.
+type Problem struct {
+	Type     string               `json:"type"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Detail   string               `json:"detail"`
+	Instance string               `json:"instance"`
+	Errors   []ierrors.FieldError `json:"errors,omitempty"`
+}
+
+type problemType struct {
+	uri    string
+	status int
+}
+
+// problemTypeOf returns the problem type of every kind of error, anything else is an internal
+// error described by the status code alone.
+func problemTypeOf(kind ierrors.Kind) (problemType, bool) {
+	switch kind {
+	case ierrors.KindNotFound:
+		return problemType{uri: "/problems/not-found", status: http.StatusNotFound}, true
+	case ierrors.KindConflict:
+		return problemType{uri: "/problems/conflict", status: http.StatusConflict}, true
+	case ierrors.KindValidation:
+		return problemType{uri: "/problems/validation", status: http.StatusBadRequest}, true
+	case ierrors.KindUnavailable:
+		return problemType{uri: "/problems/unavailable", status: http.StatusServiceUnavailable}, true
+	case ierrors.KindUnauthorized:
+		return problemType{uri: "/problems/unauthorized", status: http.StatusUnauthorized}, true
+	case ierrors.KindForbidden:
+		return problemType{uri: "/problems/forbidden", status: http.StatusForbidden}, true
+	case ierrors.KindInternal:
+	}
+
+	return problemType{}, false
+}
+
+// NewProblem describes err as a problem of the request to instance. The message of unknown
+// errors is never shown to the client.
+func NewProblem(err error, instance string) Problem {
+	var domainError ierrors.Error
+
+	t, ok := problemTypeOf(ierrors.KindOf(err))
+	if !ok || !errors.As(err, &domainError) {
+		return Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(http.StatusInternalServerError),
+			Status:   http.StatusInternalServerError,
+			Detail:   internalErrorDetail,
+			Instance: instance,
+		}
+	}
+
+	return Problem{
+		Type:     t.uri,
+		Title:    http.StatusText(t.status),
+		Status:   t.status,
+		Detail:   domainError.Message,
+		Instance: instance,
+		Errors:   domainError.Fields,
+	}
+}
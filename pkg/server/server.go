@@ -1,15 +1,26 @@
 package server
 
 import (
-	"errors"
+	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
+	"tour-le-shit-go/internal/auth"
 	"tour-le-shit-go/internal/ierrors"
 	"tour-le-shit-go/internal/logger"
+	auditRoute "tour-le-shit-go/internal/routes/audit"
+	authRoute "tour-le-shit-go/internal/routes/auth"
+	"tour-le-shit-go/internal/routes/courses"
+	"tour-le-shit-go/internal/routes/halloffame"
+	"tour-le-shit-go/internal/routes/handicap"
 	"tour-le-shit-go/internal/routes/members"
+	"tour-le-shit-go/internal/routes/rules"
 	"tour-le-shit-go/internal/routes/scoreboard"
 	"tour-le-shit-go/internal/routes/scores"
+	"tour-le-shit-go/internal/routes/seasons"
 
 	"github.com/gorilla/mux"
 )
@@ -19,28 +30,95 @@ type Server struct {
 }
 
 type Config struct {
+	// Auth authenticates the requests, every route is open when it is nil.
+	Auth            auth.Service
+	AuditRoute      auditRoute.Route
+	AuthRoute       authRoute.Route
+	CoursesRoute    courses.Route
+	HallOfFameRoute halloffame.Route
+	HandicapRoute   handicap.Route
 	MembersRoute    members.Route
 	Port            string
+	RequestTimeout  time.Duration
+	RulesRoute      rules.Route
 	ScoresRoute     scores.Route
 	ScoreboardRoute scoreboard.Route
+	SeasonsRoute    seasons.Route
 }
 
 type rootHandler func(http.ResponseWriter, *http.Request) error
 
 const Timeout = 5 * time.Second
 
+// RequestTimeout is the default deadline of a request, the queries of the request are cancelled
+// when it passes.
+const RequestTimeout = 10 * time.Second
+
 // New creates a http.Server with routes configured.
 func New(cfg Config) *http.Server {
 	s := new(Server)
 	router := mux.NewRouter()
 
-	router.Handle("/scoreboard", rootHandler(cfg.ScoreboardRoute.ScoreboardRouteHandler))
-	router.Handle("/scores", rootHandler(cfg.ScoresRoute.ScoresRouteHandler))
-	router.Handle("/scores/{id}", rootHandler(cfg.ScoresRoute.ScoreRouteHandler))
-	router.Handle("/members/{id}", rootHandler(cfg.MembersRoute.MemberRouteHandler))
-	router.Handle("/members", rootHandler(cfg.MembersRoute.MembersRouteHandler))
+	router.Handle("/scoreboard", rootHandler(cfg.ScoreboardRoute.ScoreboardRouteHandler)).Methods("GET")
+	router.Handle("/scores", rootHandler(cfg.ScoresRoute.GetScoresRouteHandler)).Methods("GET")
+	router.Handle("/scores", rootHandler(cfg.ScoresRoute.PutScoresRouteHandler)).Methods("PUT")
+	router.Handle("/scores/{id}/restore", rootHandler(cfg.ScoresRoute.PostScoreRestoreRouteHandler)).Methods("POST")
+	router.Handle("/scores/{id}", rootHandler(cfg.ScoresRoute.GetScoreRouteHandler)).Methods("GET")
+	router.Handle("/scores/{id}", rootHandler(cfg.ScoresRoute.PatchScoreRouteHandler)).Methods("PATCH")
+	router.Handle("/scores/{id}", rootHandler(cfg.ScoresRoute.DeleteScoreRouteHandler)).Methods("DELETE")
+	router.Handle("/members/{id}/archive", rootHandler(cfg.MembersRoute.PostMemberArchiveRouteHandler)).Methods("POST")
+	router.Handle("/members/{id}/unarchive", rootHandler(cfg.MembersRoute.PostMemberUnarchiveRouteHandler)).Methods("POST")
+	router.Handle("/members/{id}/restore", rootHandler(cfg.MembersRoute.PostMemberRestoreRouteHandler)).Methods("POST")
+	router.Handle("/members/{id}/handicap", rootHandler(cfg.HandicapRoute.HandicapRouteHandler)).Methods("GET")
+	router.Handle("/members/{id}", rootHandler(cfg.MembersRoute.GetMemberRouteHandler)).Methods("GET")
+	router.Handle("/members/{id}", rootHandler(cfg.MembersRoute.PostMemberRouteHandler)).Methods("POST")
+	router.Handle("/members/{id}", rootHandler(cfg.MembersRoute.DeleteMemberRouteHandler)).Methods("DELETE")
+	router.Handle("/members", rootHandler(cfg.MembersRoute.GetMembersRouteHandler)).Methods("GET")
+	router.Handle("/members", rootHandler(cfg.MembersRoute.PutMembersRouteHandler)).Methods("PUT")
+	router.Handle("/v2/members/{id}", rootHandler(cfg.MembersRoute.GetMemberRouteHandler)).Methods("GET")
+	router.Handle("/v2/members/{id}", rootHandler(cfg.MembersRoute.PutMemberV2RouteHandler)).Methods("PUT")
+	router.Handle("/v2/members/{id}", rootHandler(cfg.MembersRoute.PatchMemberV2RouteHandler)).Methods("PATCH")
+	router.Handle("/v2/members/{id}", rootHandler(cfg.MembersRoute.DeleteMemberV2RouteHandler)).Methods("DELETE")
+	router.Handle("/v2/members", rootHandler(cfg.MembersRoute.GetMembersRouteHandler)).Methods("GET")
+	router.Handle("/v2/members", rootHandler(cfg.MembersRoute.PostMembersV2RouteHandler)).Methods("POST")
+	router.Handle("/courses/{id}", rootHandler(cfg.CoursesRoute.GetCourseRouteHandler)).Methods("GET")
+	router.Handle("/courses/{id}", rootHandler(cfg.CoursesRoute.PostCourseRouteHandler)).Methods("POST")
+	router.Handle("/courses/{id}", rootHandler(cfg.CoursesRoute.DeleteCourseRouteHandler)).Methods("DELETE")
+	router.Handle("/courses", rootHandler(cfg.CoursesRoute.GetCoursesRouteHandler)).Methods("GET")
+	router.Handle("/courses", rootHandler(cfg.CoursesRoute.PutCoursesRouteHandler)).Methods("PUT")
+	router.Handle("/seasons/current", rootHandler(cfg.SeasonsRoute.CurrentSeasonRouteHandler)).Methods("GET")
+	router.Handle("/seasons/{id}/close", rootHandler(cfg.HallOfFameRoute.CloseSeasonRouteHandler)).Methods("POST")
+	router.Handle("/seasons/{id}", rootHandler(cfg.SeasonsRoute.GetSeasonRouteHandler)).Methods("GET")
+	router.Handle("/seasons/{id}", rootHandler(cfg.SeasonsRoute.PostSeasonRouteHandler)).Methods("POST")
+	router.Handle("/seasons", rootHandler(cfg.SeasonsRoute.GetSeasonsRouteHandler)).Methods("GET")
+	router.Handle("/seasons", rootHandler(cfg.SeasonsRoute.PutSeasonsRouteHandler)).Methods("PUT")
+	router.Handle("/halloffame/{id}", rootHandler(cfg.HallOfFameRoute.FinalStandingsRouteHandler)).Methods("GET")
+	router.Handle("/halloffame", rootHandler(cfg.HallOfFameRoute.HallOfFameRouteHandler)).Methods("GET")
+	router.Handle("/rules", rootHandler(cfg.RulesRoute.GetRulesRouteHandler)).Methods("GET")
+	router.Handle("/rules", rootHandler(cfg.RulesRoute.PutRulesRouteHandler)).Methods("PUT")
+
+	router.Handle("/audit", rootHandler(cfg.AuditRoute.GetAuditRouteHandler)).Methods("GET")
+
+	router.Handle("/auth/login", rootHandler(cfg.AuthRoute.PostLoginRouteHandler)).Methods("POST")
+	router.Handle("/auth/users", rootHandler(cfg.AuthRoute.PostUsersRouteHandler)).Methods("POST")
+	router.Handle("/auth/me", rootHandler(cfg.AuthRoute.GetMeRouteHandler)).Methods("GET")
+	router.Handle("/auth/tokens/{id}", rootHandler(cfg.AuthRoute.DeleteTokenRouteHandler)).Methods("DELETE")
+	router.Handle("/auth/tokens", rootHandler(cfg.AuthRoute.GetTokensRouteHandler)).Methods("GET")
+	router.Handle("/auth/tokens", rootHandler(cfg.AuthRoute.PostTokensRouteHandler)).Methods("POST")
+
+	if cfg.Auth != nil {
+		router.Use(authenticate(cfg.Auth))
+	}
+
+	router.NotFoundHandler = rootHandler(notFound)
+	router.MethodNotAllowedHandler = methodNotAllowed(router)
 
-	s.Handler = logger.RequestLogger(router)
+	timeout := cfg.RequestTimeout
+	if timeout == 0 {
+		timeout = RequestTimeout
+	}
+
+	s.Handler = logger.RequestLogger(withDeadline(router, timeout))
 
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
@@ -51,6 +129,17 @@ func New(cfg Config) *http.Server {
 	return srv
 }
 
+// withDeadline sets a deadline on the context of every request. The context is also cancelled
+// when the client disconnects.
+func withDeadline(h http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (fn rootHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err := fn(w, r)
 	if err == nil {
@@ -58,20 +147,67 @@ func (fn rootHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Printf("an error occurred %v", err)
-	w.Header().Set("content-type", "application/problem+json")
 
-	var httpError ierrors.HttpError
+	writeProblem(w, NewProblem(err, r.URL.RequestURI()))
+}
 
-	ok := errors.As(err, &httpError)
-	if !ok {
-		w.WriteHeader(ierrors.ServerErrorStatusCode)
+func notFound(_ http.ResponseWriter, req *http.Request) error {
+	return ierrors.NotFound(fmt.Sprintf("%s does not exist", req.URL.Path))
+}
 
-		return
+// methodNotAllowed answers requests to a path that exists with a method that does not, the Allow
+// header lists the methods of the path.
+func methodNotAllowed(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed := allowedMethods(router, r)
+
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeProblem(w, Problem{
+			Type:     "/problems/method-not-allowed",
+			Title:    http.StatusText(http.StatusMethodNotAllowed),
+			Status:   http.StatusMethodNotAllowed,
+			Detail:   fmt.Sprintf("method %s is not allowed, expected one of %s", r.Method, strings.Join(allowed, ", ")),
+			Instance: r.URL.RequestURI(),
+		})
+	})
+}
+
+// allowedMethods returns the methods of the routes matching the path of r.
+func allowedMethods(router *mux.Router, r *http.Request) []string {
+	allowed := make([]string, 0)
+
+	_ = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		for _, method := range methods {
+			candidate := r.Clone(r.Context())
+			candidate.Method = method
+
+			var match mux.RouteMatch
+			if route.Match(candidate, &match) {
+				allowed = append(allowed, method)
+			}
+		}
+
+		return nil
+	})
+
+	return allowed
+}
+
+func writeProblem(w http.ResponseWriter, problem Problem) {
+	b, err := json.Marshal(problem)
+	if err != nil {
+		panic(err)
 	}
 
-	w.WriteHeader(httpError.Code)
+	w.Header().Set("content-type", ProblemContentType)
+	w.WriteHeader(problem.Status)
 
-	_, err = w.Write(httpError.PrintBody())
+	_, err = w.Write(b)
 	if err != nil {
 		panic(err)
 	}
@@ -3,32 +3,160 @@ package server_test
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"tour-le-shit-go/internal/audit"
+	auditMock "tour-le-shit-go/internal/audit/mock"
+	auditModel "tour-le-shit-go/internal/audit/model"
+	"tour-le-shit-go/internal/auth"
+	authMock "tour-le-shit-go/internal/auth/mock"
+	authModel "tour-le-shit-go/internal/auth/model"
+	"tour-le-shit-go/internal/courses"
+	coursesMock "tour-le-shit-go/internal/courses/mock"
+	coursesModel "tour-le-shit-go/internal/courses/model"
+	"tour-le-shit-go/internal/halloffame"
+	hallOfFameMock "tour-le-shit-go/internal/halloffame/mock"
+	hallOfFameModel "tour-le-shit-go/internal/halloffame/model"
+	"tour-le-shit-go/internal/handicap"
 	"tour-le-shit-go/internal/players"
+	playersDb "tour-le-shit-go/internal/players/db"
 	playersMock "tour-le-shit-go/internal/players/mock"
 	playersModel "tour-le-shit-go/internal/players/model"
+	"tour-le-shit-go/internal/purge"
+	auditRoute "tour-le-shit-go/internal/routes/audit"
+	authRoute "tour-le-shit-go/internal/routes/auth"
+	coursesRoute "tour-le-shit-go/internal/routes/courses"
+	hallOfFameRoute "tour-le-shit-go/internal/routes/halloffame"
+	handicapRoute "tour-le-shit-go/internal/routes/handicap"
 	"tour-le-shit-go/internal/routes/members"
+	"tour-le-shit-go/internal/routes/rules"
 	"tour-le-shit-go/internal/routes/scoreboard"
+	"tour-le-shit-go/internal/routes/scores"
+	seasonsRoute "tour-le-shit-go/internal/routes/seasons"
 	"tour-le-shit-go/internal/score"
+	scoreDb "tour-le-shit-go/internal/score/db"
 	scoreMock "tour-le-shit-go/internal/score/mock"
 	scoreModel "tour-le-shit-go/internal/score/model"
+	"tour-le-shit-go/internal/seasons"
+	seasonsMock "tour-le-shit-go/internal/seasons/mock"
+	seasonsModel "tour-le-shit-go/internal/seasons/model"
+	"tour-le-shit-go/internal/sqlite"
+	"tour-le-shit-go/internal/utils"
 	"tour-le-shit-go/pkg/server"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 const MemberName = "Test"
 
+func newOpenSeason(id int) seasonsModel.Season {
+	return seasonsModel.Season{
+		Id:        id,
+		Name:      "Season",
+		StartDate: newDay("2000-01-01"),
+		EndDate:   newDay("2999-12-31"),
+		Status:    seasonsModel.StatusOpen,
+	}
+}
+
+// newPlayersRepository returns a players repository holding the given players and the
+// players of the scores.
+func newPlayersRepository(s []scoreModel.Score, p ...playersModel.Player) *playersMock.MockedRepository {
+	known := make(map[string]bool, 0)
+	for _, player := range p {
+		known[player.Id] = true
+	}
+
+	for _, score := range s {
+		if !known[score.PlayerId] {
+			known[score.PlayerId] = true
+			p = append(p, playersModel.Player{Id: score.PlayerId, Name: score.PlayerName})
+		}
+	}
+
+	return playersMock.NewRepository(p)
+}
+
+// renamingRepository renames every player right after creating it, like a concurrent request would.
+type renamingRepository struct {
+	*playersMock.MockedRepository
+	name string
+}
+
+func (r renamingRepository) CreatePlayer(ctx context.Context, id, name string) (*playersModel.Player, error) {
+	created, err := r.MockedRepository.CreatePlayer(ctx, id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.UpdatePlayer(ctx, created.Id, r.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// unreliableSeasonsRepository fails to update a season once, like a database timing out.
+type unreliableSeasonsRepository struct {
+	*seasonsMock.MockedRepository
+	failed *atomic.Bool
+}
+
+func (r unreliableSeasonsRepository) UpdateSeason(ctx context.Context, season seasonsModel.Season) (*seasonsModel.Season, error) {
+	if r.failed.CompareAndSwap(false, true) {
+		return nil, fmt.Errorf("connection reset by peer")
+	}
+
+	return r.MockedRepository.UpdateSeason(ctx, season)
+}
+
+// failingAuditRepository can not add entries, like an audit log that is down.
+type failingAuditRepository struct {
+	*auditMock.MockedRepository
+}
+
+func (r failingAuditRepository) AddEntry(_ context.Context, _ auditModel.Entry) error {
+	return fmt.Errorf("audit log is down")
+}
+
+func newAuditRepresentations() map[string]audit.Representation {
+	return map[string]audit.Representation{
+		auditModel.EntityPlayer: members.AuditRepresentation(),
+		auditModel.EntityScore:  scores.AuditRepresentation(),
+	}
+}
+
+func newAuditService() audit.Service {
+	return audit.NewService(auditMock.NewRepository([]auditModel.Entry{}), utils.NewClock(time.UTC), newAuditRepresentations())
+}
+
+func newDay(value string) time.Time {
+	day, _ := utils.ParseDate(value)
+
+	return day
+}
+
 func TestScoreboardRoute(t *testing.T) {
 	t.Parallel()
 
 	beforeEach := func(s []scoreModel.Score) *httptest.Server {
-		scoreRepository := scoreMock.NewRepository(s)
-		scoreService := score.NewService(scoreRepository)
-		scoreboardRoute := scoreboard.NewScoreboardRoute(scoreService)
+		playersRepository := newPlayersRepository(s)
+		scoreRepository := scoreMock.NewRepository(s, playersRepository)
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		playersService := players.NewService(playersMock.NewRepository([]playersModel.Player{}), newAuditService(), utils.NewClock(time.UTC))
+		scoreService := score.NewService(scoreRepository, players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), coursesService, seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+		handicapService := handicap.NewService(playersService, scoreService, coursesService)
+		scoreboardRoute := scoreboard.NewScoreboardRoute(scoreService, handicapService)
 
 		cfg := server.Config{
 			ScoreboardRoute: scoreboardRoute,
@@ -52,7 +180,7 @@ func TestScoreboardRoute(t *testing.T) {
 			Eagles:     0,
 			Muligans:   0,
 			Season:     1,
-			Day:        "2022-01-01",
+			Day:        newDay("2022-01-01"),
 		})
 		scores = append(scores, scoreModel.Score{
 			Id:         "id2",
@@ -63,7 +191,7 @@ func TestScoreboardRoute(t *testing.T) {
 			Eagles:     0,
 			Muligans:   0,
 			Season:     1,
-			Day:        "2022-01-01",
+			Day:        newDay("2022-01-01"),
 		})
 
 		srv := beforeEach(scores)
@@ -102,7 +230,7 @@ func TestScoreboardRoute(t *testing.T) {
 
 		_ = res.Body.Close()
 	})
-	t.Run("returns 200 with empty array due to different season", func(t *testing.T) {
+	t.Run("returns 200 with players without points due to different season", func(t *testing.T) {
 		t.Parallel()
 
 		// arrange
@@ -117,7 +245,7 @@ func TestScoreboardRoute(t *testing.T) {
 			Eagles:     0,
 			Muligans:   0,
 			Season:     1,
-			Day:        "2022-01-01",
+			Day:        newDay("2022-01-01"),
 		})
 		scores = append(scores, scoreModel.Score{
 			Id:         "id2",
@@ -128,7 +256,7 @@ func TestScoreboardRoute(t *testing.T) {
 			Eagles:     0,
 			Muligans:   0,
 			Season:     1,
-			Day:        "2022-01-01",
+			Day:        newDay("2022-01-01"),
 		})
 
 		srv := beforeEach(scores)
@@ -160,9 +288,15 @@ func TestScoreboardRoute(t *testing.T) {
 			t.Errorf("expected %d got %d", expectedSeason, scoreboardResponse.Season)
 		}
 
-		expectedPlayerLength := 0
+		expectedPlayerLength := 2
 		if len(scoreboardResponse.Players) != expectedPlayerLength {
-			t.Errorf("expected %d got %d", expectedPlayerLength, len(scoreboardResponse.Players))
+			t.Fatalf("expected %d got %d", expectedPlayerLength, len(scoreboardResponse.Players))
+		}
+
+		for _, p := range scoreboardResponse.Players {
+			if p.Points != 0 || p.CountedRounds != 0 {
+				t.Errorf("expected %s without points got %d points from %d rounds", p.Id, p.Points, p.CountedRounds)
+			}
 		}
 
 		_ = res.Body.Close()
@@ -220,7 +354,7 @@ func TestCreateMembersRoute(t *testing.T) {
 
 	beforeEach := func(m []playersModel.Player) *httptest.Server {
 		playerRepository := playersMock.NewRepository(m)
-		playerService := players.NewService(playerRepository)
+		playerService := players.NewService(playerRepository, newAuditService(), utils.NewClock(time.UTC))
 		membersRoute := members.NewMemberRoute(playerService)
 
 		cfg := server.Config{
@@ -253,7 +387,7 @@ func TestCreateMembersRoute(t *testing.T) {
 		_ = res.Body.Close()
 	})
 
-	t.Run("member already exists returns 400", func(t *testing.T) {
+	t.Run("member already exists returns 409", func(t *testing.T) {
 		t.Parallel()
 		// arrange
 		m := make([]playersModel.Player, 0)
@@ -274,7 +408,7 @@ func TestCreateMembersRoute(t *testing.T) {
 		res, err := srv.Client().Do(request)
 
 		// assert
-		expected := 400
+		expected := 409
 		if err != nil {
 			t.Fatal("got error expected none")
 		}
@@ -326,7 +460,7 @@ func TestUpdateMembersRoute(t *testing.T) {
 
 	beforeEach := func(m []playersModel.Player) *httptest.Server {
 		playerRepository := playersMock.NewRepository(m)
-		playerService := players.NewService(playerRepository)
+		playerService := players.NewService(playerRepository, newAuditService(), utils.NewClock(time.UTC))
 		membersRoute := members.NewMemberRoute(playerService)
 
 		cfg := server.Config{
@@ -336,7 +470,7 @@ func TestUpdateMembersRoute(t *testing.T) {
 		return httptest.NewServer(server.New(cfg).Handler)
 	}
 
-	t.Run("member does not exist return 400", func(t *testing.T) {
+	t.Run("member does not exist return 404", func(t *testing.T) {
 		t.Parallel()
 		// arrange
 		srv := beforeEach([]playersModel.Player{})
@@ -355,7 +489,7 @@ func TestUpdateMembersRoute(t *testing.T) {
 			t.Fatal("got error expected none")
 		}
 
-		expected := 400
+		expected := 404
 
 		if res.StatusCode != expected {
 			t.Errorf("expected %d got %d", expected, res.StatusCode)
@@ -427,7 +561,7 @@ func TestDeleteMembersRoute(t *testing.T) {
 
 	beforeEach := func(m []playersModel.Player) *httptest.Server {
 		playerRepository := playersMock.NewRepository(m)
-		playerService := players.NewService(playerRepository)
+		playerService := players.NewService(playerRepository, newAuditService(), utils.NewClock(time.UTC))
 		membersRoute := members.NewMemberRoute(playerService)
 
 		cfg := server.Config{
@@ -476,4 +610,3251 @@ func TestDeleteMembersRoute(t *testing.T) {
 
 		_ = res.Body.Close()
 	})
+	t.Run("member does not exist return 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]playersModel.Player{})
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "DELETE", srv.URL+"/members/unknown", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatal("got error expected none")
+		}
+
+		expected := 404
+
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+}
+
+func TestGetMemberRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(m []playersModel.Player) *httptest.Server {
+		cfg := server.Config{
+			MembersRoute: members.NewMemberRoute(players.NewService(playersMock.NewRepository(m), newAuditService(), utils.NewClock(time.UTC))),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	t.Run("return 200 with the member", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]playersModel.Player{{Id: "abc-123", Name: MemberName}})
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/members/abc-123", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatal("got error expected none")
+		}
+
+		expected := 200
+
+		if res.StatusCode != expected {
+			t.Fatalf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		var member members.Member
+		b, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(b, &member)
+
+		if member.Id != "abc-123" || member.Name != MemberName {
+			t.Errorf("expected %s got %v", MemberName, member)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("member does not exist return 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]playersModel.Player{})
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/members/unknown", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatal("got error expected none")
+		}
+
+		expected := 404
+
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+}
+
+func TestMembersV2Route(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(m ...playersModel.Player) *httptest.Server {
+		cfg := server.Config{
+			MembersRoute: members.NewMemberRoute(players.NewService(playersMock.NewRepository(m), newAuditService(), utils.NewClock(time.UTC))),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	do := func(t *testing.T, srv *httptest.Server, method, path, body string) (*http.Response, members.Member) {
+		t.Helper()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		defer func() { _ = res.Body.Close() }()
+
+		var member members.Member
+		b, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(b, &member)
+
+		return res, member
+	}
+
+	t.Run("post creates the member and returns 201 with its location", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		res, created := do(t, srv, "POST", "/v2/members", `{"name": "Alice"}`)
+
+		// assert
+		expected := 201
+		if res.StatusCode != expected {
+			t.Fatalf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		if created.Id == "" || created.Name != "Alice" {
+			t.Errorf("expected Alice got %v", created)
+		}
+
+		location := res.Header.Get("Location")
+		if location != "/v2/members/"+created.Id {
+			t.Errorf("expected %s got %s", "/v2/members/"+created.Id, location)
+		}
+
+		res, fetched := do(t, srv, "GET", location, "")
+		if res.StatusCode != 200 || fetched != created {
+			t.Errorf("expected %d with %v got %d with %v", 200, created, res.StatusCode, fetched)
+		}
+	})
+	t.Run("post returns the created member even when it is renamed right away", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		repository := renamingRepository{MockedRepository: playersMock.NewRepository([]playersModel.Player{}), name: "Alicia"}
+		cfg := server.Config{
+			MembersRoute: members.NewMemberRoute(players.NewService(repository, newAuditService(), utils.NewClock(time.UTC))),
+		}
+		srv := httptest.NewServer(server.New(cfg).Handler)
+		defer srv.Close()
+
+		// act
+		res, created := do(t, srv, "POST", "/v2/members", `{"name": "Alice"}`)
+
+		// assert
+		expected := 201
+		if res.StatusCode != expected {
+			t.Fatalf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		location := res.Header.Get("Location")
+		if created.Id == "" || location != "/v2/members/"+created.Id {
+			t.Errorf("expected %s got %s", "/v2/members/"+created.Id, location)
+		}
+	})
+	t.Run("post without name return 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		res, _ := do(t, srv, "POST", "/v2/members", `{}`)
+
+		// assert
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+	})
+	t.Run("post of existing name return 409", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(playersModel.Player{Id: "1", Name: "Alice"})
+		defer srv.Close()
+
+		// act
+		res, _ := do(t, srv, "POST", "/v2/members", `{"name": "Alice"}`)
+
+		// assert
+		expected := 409
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+	})
+	t.Run("put and patch return the updated member", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(playersModel.Player{Id: "1", Name: "Alice"}, playersModel.Player{Id: "2", Name: "Bob"})
+		defer srv.Close()
+
+		cases := []struct {
+			method   string
+			body     string
+			expected string
+		}{
+			{method: "PUT", body: `{"name": "Carol"}`, expected: "Carol"},
+			{method: "PATCH", body: `{}`, expected: "Carol"},
+			{method: "PATCH", body: `{"name": "Dave"}`, expected: "Dave"},
+		}
+
+		for _, c := range cases {
+			// act
+			res, updated := do(t, srv, c.method, "/v2/members/1", c.body)
+
+			// assert
+			if res.StatusCode != 200 || updated.Id != "1" || updated.Name != c.expected {
+				t.Errorf("expected %d with %s got %d with %v", 200, c.expected, res.StatusCode, updated)
+			}
+		}
+	})
+	t.Run("put without name return 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(playersModel.Player{Id: "1", Name: "Alice"})
+		defer srv.Close()
+
+		// act
+		res, _ := do(t, srv, "PUT", "/v2/members/1", `{}`)
+
+		// assert
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+	})
+	t.Run("unknown member return 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		for _, method := range []string{"GET", "PUT", "PATCH", "DELETE"} {
+			// act
+			res, _ := do(t, srv, method, "/v2/members/unknown", `{"name": "Alice"}`)
+
+			// assert
+			expected := 404
+			if res.StatusCode != expected {
+				t.Errorf("expected %d got %d for %s", expected, res.StatusCode, method)
+			}
+		}
+	})
+	t.Run("delete return 204", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(playersModel.Player{Id: "1", Name: "Alice"})
+		defer srv.Close()
+
+		// act
+		res, _ := do(t, srv, "DELETE", "/v2/members/1", "")
+
+		// assert
+		expected := 204
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		res, _ = do(t, srv, "GET", "/v2/members/1", "")
+		if res.StatusCode != 404 {
+			t.Errorf("expected %d got %d", 404, res.StatusCode)
+		}
+	})
+}
+
+func TestRulesRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(s []scoreModel.Score) *httptest.Server {
+		playersRepository := newPlayersRepository(s)
+		scoreRepository := scoreMock.NewRepository(s, playersRepository)
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		playersService := players.NewService(playersMock.NewRepository([]playersModel.Player{}), newAuditService(), utils.NewClock(time.UTC))
+		scoreService := score.NewService(scoreRepository, players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), coursesService, seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+		handicapService := handicap.NewService(playersService, scoreService, coursesService)
+
+		cfg := server.Config{
+			RulesRoute:      rules.NewRulesRoute(scoreService),
+			ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicapService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	t.Run("returns default rules when none are stored", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/rules?season=1", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var rulesResponse rules.Rules
+		b, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(b, &rulesResponse)
+
+		expectedBirdieMultiplier := score.DefaultBirdieMultiplier
+		if rulesResponse.BirdieMultiplier != expectedBirdieMultiplier {
+			t.Errorf("expected %d got %d", expectedBirdieMultiplier, rulesResponse.BirdieMultiplier)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("missing or non-positive season returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		for _, body := range []string{`{"birdieMultiplier": 2}`, `{"season": -1, "birdieMultiplier": 2}`} {
+			request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/rules", strings.NewReader(body))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			if res.StatusCode != 400 {
+				t.Errorf("expected %d got %d for %s", 400, res.StatusCode, body)
+			}
+
+			_ = res.Body.Close()
+		}
+	})
+	t.Run("negative multiplier returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		b, _ := json.Marshal(rules.Rules{Season: 1, BirdieMultiplier: -1})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/rules", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("stored rules are applied to the scoreboard", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		scores := make([]scoreModel.Score, 0)
+		scores = append(scores, scoreModel.Score{
+			Id:         "id1",
+			PlayerId:   "Player1",
+			PlayerName: "Player1",
+			Points:     30,
+			Birdies:    3,
+			Eagles:     1,
+			Muligans:   1,
+			Season:     1,
+			Day:        newDay("2022-01-01"),
+		})
+
+		srv := beforeEach(scores)
+		defer srv.Close()
+
+		b, _ := json.Marshal(rules.Rules{Season: 1, BirdieMultiplier: 1, EagleMultiplier: 5, MuliganDiminisher: 0, MaxBirdies: 2})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/rules", bytes.NewReader(b))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		// act
+		request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1", strings.NewReader(""))
+		res, err = srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var scoreboardResponse scoreboard.Scoreboard
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &scoreboardResponse)
+
+		if len(scoreboardResponse.Players) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(scoreboardResponse.Players))
+		}
+
+		expectedPoints := 37
+		if scoreboardResponse.Players[0].Points != expectedPoints {
+			t.Errorf("expected %d got %d", expectedPoints, scoreboardResponse.Players[0].Points)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("unknown tiebreaker or shared not last returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		for _, tiebreakers := range [][]string{{"coinflip"}, {"shared", "birdies"}} {
+			b, _ := json.Marshal(rules.Rules{Season: 1, Tiebreakers: tiebreakers})
+			request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/rules", bytes.NewReader(b))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expected := 400
+			if res.StatusCode != expected {
+				t.Errorf("%v expected %d got %d", tiebreakers, expected, res.StatusCode)
+			}
+
+			_ = res.Body.Close()
+		}
+	})
+	t.Run("tiebreakers of the season decide or share positions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tc := range []struct {
+			tiebreakers    []string
+			expectedFirst  string
+			expectedLabels []string
+			expectedReason string
+		}{
+			{[]string{"birdies"}, "Player2", []string{"1", "2"}, "birdies"},
+			{[]string{"muligans", "shared"}, "Player1", []string{"T1", "T1"}, "shared"},
+		} {
+			// arrange
+			srv := beforeEach([]scoreModel.Score{
+				{Id: "id1", PlayerId: "Player1", PlayerName: "Player1", Points: 30, Birdies: 1, Season: 1, Day: newDay("2022-01-02")},
+				{Id: "id2", PlayerId: "Player2", PlayerName: "Player2", Points: 30, Birdies: 2, Season: 1, Day: newDay("2022-01-01")},
+			})
+
+			b, _ := json.Marshal(rules.Rules{Season: 1, Tiebreakers: tc.tiebreakers})
+			request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/rules", bytes.NewReader(b))
+
+			res, err := srv.Client().Do(request)
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			_ = res.Body.Close()
+
+			// act
+			request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1", strings.NewReader(""))
+			res, err = srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			var scoreboardResponse scoreboard.Scoreboard
+			body, _ := io.ReadAll(res.Body)
+			_ = json.Unmarshal(body, &scoreboardResponse)
+
+			if len(scoreboardResponse.Players) != 2 {
+				t.Fatalf("expected %d got %d", 2, len(scoreboardResponse.Players))
+			}
+
+			first, second := scoreboardResponse.Players[0], scoreboardResponse.Players[1]
+			if first.Id != tc.expectedFirst {
+				t.Errorf("%v expected %s first got %s", tc.tiebreakers, tc.expectedFirst, first.Id)
+			}
+
+			if first.PositionLabel != tc.expectedLabels[0] || second.PositionLabel != tc.expectedLabels[1] {
+				t.Errorf("%v expected %v got %s and %s", tc.tiebreakers, tc.expectedLabels, first.PositionLabel, second.PositionLabel)
+			}
+
+			if second.Tiebreaker != tc.expectedReason {
+				t.Errorf("%v expected %s got %s", tc.tiebreakers, tc.expectedReason, second.Tiebreaker)
+			}
+
+			_ = res.Body.Close()
+			srv.Close()
+		}
+	})
+
+	t.Run("best rounds count and players below minimum rounds are placed last", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]scoreModel.Score{
+			{Id: "id1", PlayerId: "Player1", PlayerName: "Player1", Points: 10, Season: 1, Day: newDay("2022-01-01")},
+			{Id: "id2", PlayerId: "Player1", PlayerName: "Player1", Points: 20, Season: 1, Day: newDay("2022-01-02")},
+			{Id: "id3", PlayerId: "Player1", PlayerName: "Player1", Points: 30, Season: 1, Day: newDay("2022-01-03")},
+			{Id: "id4", PlayerId: "Player2", PlayerName: "Player2", Points: 40, Season: 1, Day: newDay("2022-01-01")},
+			{Id: "id5", PlayerId: "Player2", PlayerName: "Player2", Points: 40, Season: 1, Day: newDay("2022-01-02")},
+		})
+		defer srv.Close()
+
+		b, _ := json.Marshal(rules.Rules{Season: 1, BestRounds: 2, MinRounds: 3})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/rules", bytes.NewReader(b))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		// act
+		request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1", strings.NewReader(""))
+		res, err = srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var scoreboardResponse scoreboard.Scoreboard
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &scoreboardResponse)
+
+		if len(scoreboardResponse.Players) != 2 {
+			t.Fatalf("expected %d got %d", 2, len(scoreboardResponse.Players))
+		}
+
+		first := scoreboardResponse.Players[0]
+		if first.Id != "Player1" || first.Points != 50 || first.CountedRounds != 2 || first.DroppedRounds != 1 {
+			t.Errorf("expected Player1 with 50 points from 2 rounds dropping 1 got %s with %d points from %d rounds dropping %d",
+				first.Id, first.Points, first.CountedRounds, first.DroppedRounds)
+		}
+
+		if scoreboardResponse.Players[1].Qualified {
+			t.Errorf("expected %s not to be qualified", scoreboardResponse.Players[1].Id)
+		}
+
+		_ = res.Body.Close()
+	})
+
+}
+
+func TestAddScoreRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(s []scoreModel.Score) *httptest.Server {
+		playersRepository := newPlayersRepository(s, playersModel.Player{Id: "Player1", Name: "Player1"})
+		scoreRepository := scoreMock.NewRepository(s, playersRepository)
+		scoreService := score.NewService(scoreRepository, players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})), seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			ScoresRoute: scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	t.Run("hole by hole round derives stableford points, birdies and eagles", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		input := scores.ScoreRequest{
+			PlayerId: "Player1",
+			Season:   1,
+			Handicap: 18,
+			Holes: []scores.Hole{
+				{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 3},
+				{Number: 2, Par: 5, StrokeIndex: 2, Strokes: 3},
+				{Number: 3, Par: 3, StrokeIndex: 3, Strokes: 7},
+			},
+		}
+		b, _ := json.Marshal(input)
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expectedStatusCode := 201
+		if res.StatusCode != expectedStatusCode {
+			t.Fatalf("expected %d got %d", expectedStatusCode, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+
+		request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scores?season=1&playerId=Player1", strings.NewReader(""))
+		res, err = srv.Client().Do(request)
+
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scores.Response
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if len(response.Scores) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(response.Scores))
+		}
+
+		round := response.Scores[0]
+		if round.Points != 7 || round.Birdies != 1 || round.Eagles != 1 || round.Handicap != 18 {
+			t.Errorf("expected 7 gross points, 1 birdie, 1 eagle and handicap 18 got %d points, %d birdies, %d eagles and handicap %d", round.Points, round.Birdies, round.Eagles, round.Handicap)
+		}
+
+		if len(round.Holes) != 3 {
+			t.Errorf("expected %d holes got %d", 3, len(round.Holes))
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("duplicate hole number returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		input := scores.ScoreRequest{
+			PlayerId: "Player1",
+			Season:   1,
+			Holes: []scores.Hole{
+				{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 4},
+				{Number: 1, Par: 4, StrokeIndex: 2, Strokes: 4},
+			},
+		}
+		b, _ := json.Marshal(input)
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expectedStatusCode := 400
+		if res.StatusCode != expectedStatusCode {
+			t.Errorf("expected %d got %d", expectedStatusCode, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("negative totals return 400", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []scores.ScoreRequest{
+			{PlayerId: "Player1", Season: 1, Points: -10},
+			{PlayerId: "Player1", Season: 1, Points: 10, Birdies: -1},
+			{PlayerId: "Player1", Season: 1, Points: 10, Eagles: -1},
+			{PlayerId: "Player1", Season: 1, Points: 10, Muligans: -1},
+			{PlayerId: "Player1", Season: 1, Muligans: -1, Holes: []scores.Hole{{Number: 1, Par: 4, StrokeIndex: 1, Strokes: 4}}},
+		} {
+			// arrange
+			srv := beforeEach(make([]scoreModel.Score, 0))
+
+			b, _ := json.Marshal(input)
+			request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expectedStatusCode := 400
+			if res.StatusCode != expectedStatusCode {
+				t.Errorf("expected %d got %d for %v", expectedStatusCode, res.StatusCode, input)
+			}
+
+			_ = res.Body.Close()
+
+			srv.Close()
+		}
+	})
+	t.Run("backdated round keeps its play date", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		b, _ := json.Marshal(scores.ScoreRequest{PlayerId: "Player1", Season: 1, Points: 10, Day: "2022-05-01"})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scores?season=1&playerId=Player1", strings.NewReader(""))
+		res, err = srv.Client().Do(request)
+
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scores.Response
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if len(response.Scores) != 1 || response.Scores[0].Day != "2022-05-01" {
+			t.Errorf("expected one round on %s got %v", "2022-05-01", response.Scores)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("play date outside of the season or in the future returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		for _, day := range []string{"1999-12-31", "2999-01-01", "01/05/2022"} {
+			// arrange
+			srv := beforeEach(make([]scoreModel.Score, 0))
+
+			b, _ := json.Marshal(scores.ScoreRequest{PlayerId: "Player1", Season: 1, Points: 10, Day: day})
+			request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expectedStatusCode := 400
+			if res.StatusCode != expectedStatusCode {
+				t.Errorf("expected %d got %d for %s", expectedStatusCode, res.StatusCode, day)
+			}
+
+			_ = res.Body.Close()
+
+			srv.Close()
+		}
+	})
+	t.Run("round without play date is stamped with today in the tour timezone", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		tour := time.FixedZone("CEST", 2*60*60)
+		clock := utils.NewClockAt(tour, func() time.Time {
+			return time.Date(2022, 6, 1, 23, 30, 0, 0, time.UTC)
+		})
+		playersRepository := newPlayersRepository(nil, playersModel.Player{Id: "Player1", Name: "Player1"})
+		scoreService := score.NewService(scoreMock.NewRepository(make([]scoreModel.Score, 0), playersRepository), players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})), seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), clock), newAuditService(), clock)
+
+		srv := httptest.NewServer(server.New(server.Config{ScoresRoute: scores.NewScoresRoute(scoreService)}).Handler)
+		defer srv.Close()
+
+		b, _ := json.Marshal(scores.ScoreRequest{PlayerId: "Player1", Season: 1, Points: 10})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scores?season=1&playerId=Player1", strings.NewReader(""))
+		res, err = srv.Client().Do(request)
+
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scores.Response
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if len(response.Scores) != 1 || response.Scores[0].Day != "2022-06-02" {
+			t.Errorf("expected one round on %s got %v", "2022-06-02", response.Scores)
+		}
+
+		_ = res.Body.Close()
+	})
+}
+
+func TestUpdateScoreRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(s []scoreModel.Score) *httptest.Server {
+		playersRepository := newPlayersRepository(s)
+		scoreRepository := scoreMock.NewRepository(s, playersRepository)
+		scoreService := score.NewService(scoreRepository, players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})), seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			ScoresRoute: scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	existing := []scoreModel.Score{
+		{Id: "id1", PlayerId: "Player1", PlayerName: "Player1", Points: 10, Birdies: 1, Season: 1, Day: newDay("2022-01-01")},
+	}
+
+	t.Run("patch changes given fields and keeps the original day", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(append([]scoreModel.Score{}, existing...))
+		defer srv.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), "PATCH", srv.URL+"/scores/id1", strings.NewReader(`{"points": 12}`))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expectedStatusCode := 200
+		if res.StatusCode != expectedStatusCode {
+			t.Fatalf("expected %d got %d", expectedStatusCode, res.StatusCode)
+		}
+
+		var response scores.ScoreResponse
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if response.Points != 12 || response.Birdies != 1 || response.Day != "2022-01-01" {
+			t.Errorf("expected 12 points, 1 birdie on 2022-01-01 got %d points, %d birdies on %s", response.Points, response.Birdies, response.Day)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("patch changes the day when given", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(append([]scoreModel.Score{}, existing...))
+		defer srv.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), "PATCH", srv.URL+"/scores/id1", strings.NewReader(`{"day": "2022-02-01"}`))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scores.ScoreResponse
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if response.Day != "2022-02-01" || response.Points != 10 {
+			t.Errorf("expected 10 points on 2022-02-01 got %d points on %s", response.Points, response.Day)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("patch with invalid fields returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{`{"birdies": -1}`, `{"day": "01/02/2022"}`, `{"points": "ten"}`} {
+			// arrange
+			srv := beforeEach(append([]scoreModel.Score{}, existing...))
+
+			request, _ := http.NewRequestWithContext(context.Background(), "PATCH", srv.URL+"/scores/id1", strings.NewReader(body))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expectedStatusCode := 400
+			if res.StatusCode != expectedStatusCode {
+				t.Errorf("expected %d got %d for %s", expectedStatusCode, res.StatusCode, body)
+			}
+
+			_ = res.Body.Close()
+
+			srv.Close()
+		}
+	})
+	t.Run("patching holes keeps the handicap of the round", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		holes := make([]scoreModel.Hole, 0)
+		patched := make([]scores.Hole, 0)
+
+		for i := 1; i <= 18; i++ {
+			holes = append(holes, scoreModel.Hole{Number: i, Par: 4, StrokeIndex: i, Strokes: 5})
+			patched = append(patched, scores.Hole{Number: i, Par: 4, StrokeIndex: i, Strokes: 5})
+		}
+
+		srv := beforeEach([]scoreModel.Score{
+			{Id: "net", PlayerId: "Player1", PlayerName: "Player1", Points: 18, Season: 1, Day: newDay("2022-01-01"), Handicap: 18, Holes: holes},
+		})
+		defer srv.Close()
+
+		b, _ := json.Marshal(map[string]any{"holes": patched})
+		request, _ := http.NewRequestWithContext(context.Background(), "PATCH", srv.URL+"/scores/net", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scores.ScoreResponse
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if res.StatusCode != 200 || response.Points != 18 || response.Handicap != 18 {
+			t.Errorf("expected 18 gross points with handicap 18 got %d, %d points and handicap %d", res.StatusCode, response.Points, response.Handicap)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("patch with empty holes or a handicap without holes returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{`{"holes": []}`, `{"handicap": 10}`} {
+			// arrange
+			srv := beforeEach(append([]scoreModel.Score{}, existing...))
+
+			request, _ := http.NewRequestWithContext(context.Background(), "PATCH", srv.URL+"/scores/id1", strings.NewReader(body))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expectedStatusCode := 400
+			if res.StatusCode != expectedStatusCode {
+				t.Errorf("expected %d got %d for %s", expectedStatusCode, res.StatusCode, body)
+			}
+
+			_ = res.Body.Close()
+
+			srv.Close()
+		}
+	})
+	t.Run("patch unknown score returns 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(append([]scoreModel.Score{}, existing...))
+		defer srv.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), "PATCH", srv.URL+"/scores/unknown", strings.NewReader(`{"points": 12}`))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expectedStatusCode := 404
+		if res.StatusCode != expectedStatusCode {
+			t.Errorf("expected %d got %d", expectedStatusCode, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+}
+
+func TestGetScoreRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(s []scoreModel.Score) *httptest.Server {
+		playersRepository := newPlayersRepository(s)
+		scoreRepository := scoreMock.NewRepository(s, playersRepository)
+		scoreService := score.NewService(scoreRepository, players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})), seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			ScoresRoute: scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	existing := []scoreModel.Score{
+		{Id: "id1", PlayerId: "Player1", PlayerName: "Player1", Points: 10, Birdies: 1, Season: 1, Day: newDay("2022-01-01")},
+	}
+
+	t.Run("return 200 with the score", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(append([]scoreModel.Score{}, existing...))
+		defer srv.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scores/id1", strings.NewReader(""))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expectedStatusCode := 200
+		if res.StatusCode != expectedStatusCode {
+			t.Fatalf("expected %d got %d", expectedStatusCode, res.StatusCode)
+		}
+
+		var response scores.ScoreResponse
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if response.Id != "id1" || response.Points != 10 || response.Birdies != 1 || response.Day != "2022-01-01" {
+			t.Errorf("expected id1 with 10 points, 1 birdie on 2022-01-01 got %v", response)
+		}
+
+		_ = res.Body.Close()
+	})
+
+	for _, method := range []string{"GET", "DELETE"} {
+		method := method
+
+		t.Run(method+" of unknown score return 404", func(t *testing.T) {
+			t.Parallel()
+
+			// arrange
+			srv := beforeEach(append([]scoreModel.Score{}, existing...))
+			defer srv.Close()
+
+			request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+"/scores/unknown", strings.NewReader(""))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expectedStatusCode := 404
+			if res.StatusCode != expectedStatusCode {
+				t.Errorf("expected %d got %d", expectedStatusCode, res.StatusCode)
+			}
+
+			_ = res.Body.Close()
+		})
+	}
+}
+
+func TestSqliteRepositories(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	database, err := sqlite.Open(t.TempDir() + "/tourleshit.db")
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	defer database.Close()
+
+	playerRepository := playersDb.NewRepository(database)
+	playerService := players.NewService(playerRepository, newAuditService(), utils.NewClock(time.UTC))
+	scoreService := score.NewService(scoreDb.NewRepository(database, playerRepository), playerService, courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})), seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+	srv := httptest.NewServer(server.New(server.Config{
+		MembersRoute:    members.NewMemberRoute(playerService),
+		ScoresRoute:     scores.NewScoresRoute(scoreService),
+		ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicap.NewService(playerService, scoreService, courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})))),
+	}).Handler)
+	defer srv.Close()
+
+	request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/members", strings.NewReader(`{"name": "Test"}`))
+
+	res, err := srv.Client().Do(request)
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	var created []members.Member
+	body, _ := io.ReadAll(res.Body)
+	_ = json.Unmarshal(body, &created)
+	_ = res.Body.Close()
+
+	if len(created) != 1 {
+		t.Fatalf("expected %d got %d", 1, len(created))
+	}
+
+	for _, day := range []string{"2022-01-01", "2022-01-02"} {
+		b, _ := json.Marshal(scores.ScoreRequest{PlayerId: created[0].Id, Season: 1, Points: 10, Birdies: 1, Day: day})
+		request, _ = http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		res, err = srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		if res.StatusCode != 201 {
+			t.Fatalf("expected %d got %d", 201, res.StatusCode)
+		}
+	}
+
+	// act
+	request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1", strings.NewReader(""))
+	res, err = srv.Client().Do(request)
+
+	// assert
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	var scoreboardResponse scoreboard.Scoreboard
+	body, _ = io.ReadAll(res.Body)
+	_ = json.Unmarshal(body, &scoreboardResponse)
+	_ = res.Body.Close()
+
+	if len(scoreboardResponse.Players) != 1 {
+		t.Fatalf("expected %d got %d", 1, len(scoreboardResponse.Players))
+	}
+
+	player := scoreboardResponse.Players[0]
+	if player.Points != 24 || player.LastPlayed != "2022-01-02" {
+		t.Errorf("expected 24 points last played 2022-01-02 got %d points last played %s", player.Points, player.LastPlayed)
+	}
+}
+
+func TestCoursesRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(c []coursesModel.Course) *httptest.Server {
+		coursesService := courses.NewService(coursesMock.NewRepository(c))
+		playersRepository := newPlayersRepository(nil, playersModel.Player{Id: "Player1", Name: "Player1"})
+		scoreService := score.NewService(scoreMock.NewRepository(make([]scoreModel.Score, 0), playersRepository), players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), coursesService, seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			CoursesRoute: coursesRoute.NewCourseRoute(coursesService),
+			ScoresRoute:  scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	newCourse := func() coursesModel.Course {
+		holes := make([]coursesModel.Hole, 0)
+		for i := 1; i <= 9; i++ {
+			holes = append(holes, coursesModel.Hole{Number: i, Par: 4, StrokeIndex: 2*i - 1})
+		}
+
+		return coursesModel.Course{
+			Id:    "course-1",
+			Name:  "Test Golf Club",
+			Holes: holes,
+			Tees:  []coursesModel.Tee{{Name: "Yellow", CourseRating: 35.2, Slope: 123}},
+		}
+	}
+
+	t.Run("create course returns 200", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]coursesModel.Course, 0))
+		defer srv.Close()
+
+		input := coursesRoute.CourseInput{Name: "New course", Tees: []coursesRoute.Tee{{Name: "Red", CourseRating: 33.1, Slope: 110}}}
+		for i := 1; i <= 9; i++ {
+			input.Holes = append(input.Holes, coursesRoute.Hole{Number: i, Par: 3, StrokeIndex: i})
+		}
+
+		b, _ := json.Marshal(input)
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/courses", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 200
+		if res.StatusCode != expected {
+			t.Fatalf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		var course coursesRoute.Course
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &course)
+
+		expectedPar := 27
+		if course.Par != expectedPar {
+			t.Errorf("expected %d got %d", expectedPar, course.Par)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("course with invalid slope returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]coursesModel.Course, 0))
+		defer srv.Close()
+
+		input := coursesRoute.CourseInput{Name: "New course", Tees: []coursesRoute.Tee{{Name: "Red", CourseRating: 33.1, Slope: 300}}}
+		for i := 1; i <= 9; i++ {
+			input.Holes = append(input.Holes, coursesRoute.Hole{Number: i, Par: 3, StrokeIndex: i})
+		}
+
+		b, _ := json.Marshal(input)
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/courses", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("unknown course returns 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]coursesModel.Course, 0))
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/courses/unknown", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 404
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("score on course takes par and stroke index from the course", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]coursesModel.Course{newCourse()})
+		defer srv.Close()
+
+		input := scores.ScoreRequest{
+			PlayerId: "Player1",
+			Season:   1,
+			CourseId: "course-1",
+			Tee:      "Yellow",
+			Holes:    []scores.Hole{{Number: 1, Strokes: 3}, {Number: 2, Strokes: 4}},
+		}
+		b, _ := json.Marshal(input)
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		// act
+		request, _ = http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scores?season=1&playerId=Player1", strings.NewReader(""))
+		res, err = srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scores.Response
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if len(response.Scores) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(response.Scores))
+		}
+
+		round := response.Scores[0]
+		if round.CourseId != "course-1" || round.Points != 5 || round.Birdies != 1 {
+			t.Errorf("expected course-1 with 5 points and 1 birdie got %s with %d points and %d birdies", round.CourseId, round.Points, round.Birdies)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("score on unknown tee returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]coursesModel.Course{newCourse()})
+		defer srv.Close()
+
+		input := scores.ScoreRequest{PlayerId: "Player1", Season: 1, CourseId: "course-1", Tee: "Black", Points: 30}
+		b, _ := json.Marshal(input)
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+}
+
+func TestHandicapRoute(t *testing.T) {
+	t.Parallel()
+
+	holes := make([]coursesModel.Hole, 0)
+	for i := 1; i <= 18; i++ {
+		holes = append(holes, coursesModel.Hole{Number: i, Par: 4, StrokeIndex: i})
+	}
+
+	course := coursesModel.Course{
+		Id:    "course-1",
+		Name:  "Test Golf Club",
+		Holes: holes,
+		Tees:  []coursesModel.Tee{{Name: "Yellow", CourseRating: 72.0, Slope: 113}},
+	}
+
+	newRound := func(id, day string, strokes int) scoreModel.Score {
+		roundHoles := make([]scoreModel.Hole, 0)
+		for _, h := range holes {
+			roundHoles = append(roundHoles, scoreModel.Hole{Number: h.Number, Par: h.Par, StrokeIndex: h.StrokeIndex, Strokes: strokes})
+		}
+
+		return scoreModel.Score{
+			Id:         id,
+			PlayerId:   "abc-123",
+			PlayerName: MemberName,
+			Season:     1,
+			Day:        newDay(day),
+			CourseId:   course.Id,
+			Tee:        "Yellow",
+			Holes:      roundHoles,
+		}
+	}
+
+	beforeEach := func(s []scoreModel.Score) *httptest.Server {
+		playersRepository := playersMock.NewRepository([]playersModel.Player{{Id: "abc-123", Name: MemberName}})
+		playersService := players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{course}))
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), playersService, coursesService, seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		handicapService := handicap.NewService(playersService, scoreService, coursesService)
+
+		cfg := server.Config{
+			HandicapRoute:   handicapRoute.NewHandicapRoute(handicapService),
+			ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicapService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	t.Run("returns handicap index after three rounds", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		rounds := []scoreModel.Score{
+			newRound("id1", "2022-05-01", 5),
+			newRound("id2", "2022-05-08", 5),
+			newRound("id3", "2022-05-15", 5),
+		}
+
+		srv := beforeEach(rounds)
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/members/abc-123/handicap", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response handicapRoute.Handicap
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if len(response.History) != 3 {
+			t.Fatalf("expected %d got %d", 3, len(response.History))
+		}
+
+		if response.History[1].HandicapIndex != nil {
+			t.Errorf("expected no handicap index after two rounds got %v", *response.History[1].HandicapIndex)
+		}
+
+		expectedIndex := 16.0
+		if response.HandicapIndex == nil || *response.HandicapIndex != expectedIndex {
+			t.Errorf("expected %.1f got %v", expectedIndex, response.HandicapIndex)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("net scoreboard adds the playing handicap to rounds after an index is established", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		rounds := []scoreModel.Score{
+			newRound("id1", "2022-05-01", 5),
+			newRound("id2", "2022-05-08", 5),
+			newRound("id3", "2022-05-15", 5),
+			newRound("id4", "2022-05-22", 5),
+		}
+		for i := range rounds {
+			rounds[i].Points = 20
+		}
+
+		srv := beforeEach(rounds)
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1&mode=net", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scoreboard.Scoreboard
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if response.Mode != "net" || len(response.Players) != 1 {
+			t.Fatalf("expected net scoreboard with 1 player got %s with %d", response.Mode, len(response.Players))
+		}
+
+		player := response.Players[0]
+
+		expectedGross := 80
+		if player.GrossPoints != expectedGross {
+			t.Errorf("expected gross %d got %d", expectedGross, player.GrossPoints)
+		}
+
+		expectedNet := 95
+		if player.NetPoints != expectedNet || player.Points != expectedNet {
+			t.Errorf("expected net %d got %d and points %d", expectedNet, player.NetPoints, player.Points)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("rounds entered with a handicap are gross and played off that handicap on the net scoreboard", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		rounds := []scoreModel.Score{
+			newRound("id1", "2022-05-01", 5),
+			newRound("id2", "2022-05-08", 5),
+			newRound("id3", "2022-05-15", 5),
+			newRound("gross", "2022-05-22", 5),
+			newRound("net", "2022-05-29", 5),
+		}
+		for i := range rounds {
+			rounds[i].Points = 20
+		}
+
+		rounds[4].Handicap = 10
+
+		srv := beforeEach(rounds)
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1&mode=net", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var response scoreboard.Scoreboard
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &response)
+
+		if len(response.Players) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(response.Players))
+		}
+
+		expectedGross := 100
+		if response.Players[0].GrossPoints != expectedGross {
+			t.Errorf("expected gross %d got %d", expectedGross, response.Players[0].GrossPoints)
+		}
+
+		expectedNet := 125
+		if response.Players[0].NetPoints != expectedNet {
+			t.Errorf("expected net %d got %d", expectedNet, response.Players[0].NetPoints)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("invalid scoreboard mode returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/scoreboard?season=1&mode=best", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("unknown member returns 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/members/unknown/handicap", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 404
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+}
+
+func TestSeasonsRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(se []seasonsModel.Season) *httptest.Server {
+		seasonsService := seasons.NewService(seasonsMock.NewRepository(se), utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		playersRepository := newPlayersRepository(nil, playersModel.Player{Id: "Player1", Name: "Player1"})
+		scoreService := score.NewService(scoreMock.NewRepository(make([]scoreModel.Score, 0), playersRepository), players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), coursesService, seasonsService, newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			ScoresRoute:  scores.NewScoresRoute(scoreService),
+			SeasonsRoute: seasonsRoute.NewSeasonRoute(seasonsService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	t.Run("create season returns 200 and is open by default", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]seasonsModel.Season, 0))
+		defer srv.Close()
+
+		b, _ := json.Marshal(seasonsRoute.Season{Id: 2023, Name: "Tour 2023", StartDate: "2023-04-01", EndDate: "2023-10-31"})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/seasons", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var season seasonsRoute.Season
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &season)
+
+		if res.StatusCode != 200 || season.Status != seasonsModel.StatusOpen {
+			t.Errorf("expected 200 and open season got %d and %s", res.StatusCode, season.Status)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("season ending before it starts returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]seasonsModel.Season, 0))
+		defer srv.Close()
+
+		b, _ := json.Marshal(seasonsRoute.Season{Id: 2023, Name: "Tour 2023", StartDate: "2023-04-01", EndDate: "2022-10-31"})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/seasons", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("current season returns the open season of today", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		closed := newOpenSeason(1)
+		closed.Status = seasonsModel.StatusClosed
+		srv := beforeEach([]seasonsModel.Season{closed, newOpenSeason(2)})
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/seasons/current", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var season seasonsRoute.Season
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &season)
+
+		expectedId := 2
+		if season.Id != expectedId {
+			t.Errorf("expected %d got %d", expectedId, season.Id)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("current season is the season of today in the tour timezone", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		tour := time.FixedZone("CEST", 2*60*60)
+		clock := utils.NewClockAt(tour, func() time.Time {
+			return time.Date(2022, 6, 30, 23, 30, 0, 0, time.UTC)
+		})
+		june := seasonsModel.Season{Id: 1, Name: "June", StartDate: newDay("2022-06-01"), EndDate: newDay("2022-06-30"), Status: seasonsModel.StatusOpen}
+		july := seasonsModel.Season{Id: 2, Name: "July", StartDate: newDay("2022-07-01"), EndDate: newDay("2022-07-31"), Status: seasonsModel.StatusOpen}
+		seasonsService := seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{june, july}), clock)
+
+		srv := httptest.NewServer(server.New(server.Config{SeasonsRoute: seasonsRoute.NewSeasonRoute(seasonsService)}).Handler)
+		defer srv.Close()
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/seasons/current", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var season seasonsRoute.Season
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &season)
+
+		expectedId := 2
+		if season.Id != expectedId {
+			t.Errorf("expected %d got %d", expectedId, season.Id)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("status can only be changed by closing the season", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		closed := newOpenSeason(1)
+		closed.Status = seasonsModel.StatusClosed
+		srv := beforeEach([]seasonsModel.Season{closed, newOpenSeason(2)})
+		defer srv.Close()
+
+		requests := []struct {
+			method   string
+			path     string
+			season   seasonsRoute.Season
+			expected int
+		}{
+			{"PUT", "/seasons", seasonsRoute.Season{Id: 3, Name: "Tour", StartDate: "2023-04-01", EndDate: "2023-10-31", Status: seasonsModel.StatusClosed}, 400},
+			{"POST", "/seasons/2", seasonsRoute.Season{Name: "Tour", StartDate: "2000-01-01", EndDate: "2999-12-31", Status: seasonsModel.StatusClosed}, 400},
+			{"POST", "/seasons/1", seasonsRoute.Season{Name: "Tour", StartDate: "2000-01-01", EndDate: "2999-12-31", Status: seasonsModel.StatusOpen}, 409},
+			{"POST", "/seasons/1", seasonsRoute.Season{Name: "Renamed", StartDate: "2000-01-01", EndDate: "2999-12-31"}, 200},
+		}
+
+		for _, r := range requests {
+			b, _ := json.Marshal(r.season)
+			request, _ := http.NewRequestWithContext(context.Background(), r.method, srv.URL+r.path, bytes.NewReader(b))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			if res.StatusCode != r.expected {
+				t.Errorf("expected %d got %d for %s %s", r.expected, res.StatusCode, r.method, r.path)
+			}
+
+			_ = res.Body.Close()
+		}
+
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/seasons/1", strings.NewReader(""))
+		res, _ := srv.Client().Do(request)
+
+		var season seasonsRoute.Season
+		_ = json.NewDecoder(res.Body).Decode(&season)
+		_ = res.Body.Close()
+
+		if season.Name != "Renamed" || season.Status != seasonsModel.StatusClosed {
+			t.Errorf("expected renamed closed season got %v", season)
+		}
+	})
+	t.Run("adding score to closed or unknown season returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		closed := newOpenSeason(1)
+		closed.Status = seasonsModel.StatusClosed
+		srv := beforeEach([]seasonsModel.Season{closed})
+		defer srv.Close()
+
+		for _, season := range []int{1, 99} {
+			b, _ := json.Marshal(scores.ScoreRequest{PlayerId: "Player1", Season: season, Points: 30})
+			request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			expected := 400
+			if res.StatusCode != expected {
+				t.Errorf("season %d expected %d got %d", season, expected, res.StatusCode)
+			}
+
+			_ = res.Body.Close()
+		}
+	})
+}
+
+func TestHallOfFameRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(s []scoreModel.Score) *httptest.Server {
+		seasonsService := seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		playersRepository := newPlayersRepository(s)
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), coursesService, seasonsService, newAuditService(), utils.NewClock(time.UTC))
+		hallOfFameService := halloffame.NewService(hallOfFameMock.NewRepository([]hallOfFameModel.FinalStandings{}), seasonsService, scoreService, utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			HallOfFameRoute: hallOfFameRoute.NewHallOfFameRoute(hallOfFameService),
+			ScoresRoute:     scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	closeSeason := func(t *testing.T, srv *httptest.Server) int {
+		t.Helper()
+
+		request, _ := http.NewRequestWithContext(context.Background(), "POST", srv.URL+"/seasons/1/close", strings.NewReader(""))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	t.Run("closing a season freezes the champion and the shit holder", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach([]scoreModel.Score{
+			{Id: "id1", PlayerId: "Player1", PlayerName: "Player1", Points: 20, Season: 1, Day: newDay("2022-01-01")},
+			{Id: "id2", PlayerId: "Player2", PlayerName: "Player2", Points: 31, Season: 1, Day: newDay("2022-01-01")},
+			{Id: "id3", PlayerId: "Player3", PlayerName: "Player3", Points: 25, Season: 1, Day: newDay("2022-01-01")},
+		})
+		defer srv.Close()
+
+		if code := closeSeason(t, srv); code != 200 {
+			t.Fatalf("expected %d got %d", 200, code)
+		}
+
+		// act
+		request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/halloffame", strings.NewReader(""))
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		var entries []hallOfFameRoute.Entry
+		body, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(body, &entries)
+
+		if len(entries) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(entries))
+		}
+
+		if entries[0].Champion.PlayerId != "Player2" || entries[0].ShitHolder.PlayerId != "Player1" {
+			t.Errorf("expected Player2 and Player1 got %s and %s", entries[0].Champion.PlayerId, entries[0].ShitHolder.PlayerId)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("closed season rejects scores and can not be closed again", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(make([]scoreModel.Score, 0))
+		defer srv.Close()
+
+		if code := closeSeason(t, srv); code != 200 {
+			t.Fatalf("expected %d got %d", 200, code)
+		}
+
+		b, _ := json.Marshal(scores.ScoreRequest{PlayerId: "Player1", Season: 1, Points: 30})
+		request, _ := http.NewRequestWithContext(context.Background(), "PUT", srv.URL+"/scores", bytes.NewReader(b))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		expected := 400
+		if res.StatusCode != expected {
+			t.Errorf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		if code := closeSeason(t, srv); code != 409 {
+			t.Errorf("expected %d got %d", 409, code)
+		}
+
+		_ = res.Body.Close()
+	})
+	t.Run("closing again succeeds when the season could not be closed", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		s := []scoreModel.Score{
+			{Id: "id1", PlayerId: "Player1", PlayerName: "Player1", Points: 20, Season: 1, Day: newDay("2022-01-01")},
+			{Id: "id2", PlayerId: "Player2", PlayerName: "Player2", Points: 31, Season: 1, Day: newDay("2022-01-01")},
+		}
+		seasonsRepository := unreliableSeasonsRepository{seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), &atomic.Bool{}}
+		seasonsService := seasons.NewService(seasonsRepository, utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		playersRepository := newPlayersRepository(s)
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), coursesService, seasonsService, newAuditService(), utils.NewClock(time.UTC))
+		hallOfFameService := halloffame.NewService(hallOfFameMock.NewRepository([]hallOfFameModel.FinalStandings{}), seasonsService, scoreService, utils.NewClock(time.UTC))
+
+		srv := httptest.NewServer(server.New(server.Config{HallOfFameRoute: hallOfFameRoute.NewHallOfFameRoute(hallOfFameService)}).Handler)
+		defer srv.Close()
+
+		if code := closeSeason(t, srv); code != 500 {
+			t.Fatalf("expected %d got %d", 500, code)
+		}
+
+		// act
+		code := closeSeason(t, srv)
+
+		// assert
+		if code != 200 {
+			t.Fatalf("expected %d got %d", 200, code)
+		}
+
+		season, _ := seasonsService.GetSeason(context.Background(), 1)
+		if season == nil || season.IsOpen() {
+			t.Errorf("expected season 1 to be closed got %v", season)
+		}
+
+		all, _ := hallOfFameService.GetHallOfFame(context.Background())
+		if len(all) != 1 || len(all[0].Standings) != 2 || all[0].Standings[0].PlayerId != "Player2" {
+			t.Errorf("expected the final standings of season 1 once got %v", all)
+		}
+	})
+}
+
+// TestConcurrentRequests calls the handler straight from many goroutines, going through a real
+// connection would order the requests enough to hide data races from the race detector.
+func TestConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	const workers = 20
+
+	const roundsPerWorker = 5
+
+	// arrange
+	playersRepository := playersMock.NewRepository([]playersModel.Player{})
+	coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+	seasonsService := seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC))
+	playersService := players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))
+	scoreService := score.NewService(scoreMock.NewRepository([]scoreModel.Score{}, playersRepository), playersService, coursesService, seasonsService, newAuditService(), utils.NewClock(time.UTC))
+	handicapService := handicap.NewService(playersService, scoreService, coursesService)
+
+	cfg := server.Config{
+		CoursesRoute:    coursesRoute.NewCourseRoute(coursesService),
+		HallOfFameRoute: hallOfFameRoute.NewHallOfFameRoute(halloffame.NewService(hallOfFameMock.NewRepository([]hallOfFameModel.FinalStandings{}), seasonsService, scoreService, utils.NewClock(time.UTC))),
+		MembersRoute:    members.NewMemberRoute(playersService),
+		RulesRoute:      rules.NewRulesRoute(scoreService),
+		ScoresRoute:     scores.NewScoresRoute(scoreService),
+		ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicapService),
+		SeasonsRoute:    seasonsRoute.NewSeasonRoute(seasonsService),
+	}
+
+	handler := server.New(cfg).Handler
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			name := fmt.Sprintf("Player%d", i)
+
+			res := do("PUT", "/members", fmt.Sprintf(`{"name": "%s"}`, name))
+			if res.Code != 200 {
+				t.Errorf("expected %d got %d", 200, res.Code)
+
+				return
+			}
+
+			var all []members.Member
+			_ = json.Unmarshal(res.Body.Bytes(), &all)
+
+			playerId := ""
+
+			for _, m := range all {
+				if m.Name == name {
+					playerId = m.Id
+				}
+			}
+
+			for r := 0; r < roundsPerWorker; r++ {
+				res = do("PUT", "/scores", fmt.Sprintf(`{"playerId": "%s", "points": 10, "season": 1, "day": "2022-01-01"}`, playerId))
+				if res.Code != 201 {
+					t.Errorf("expected %d got %d", 201, res.Code)
+
+					continue
+				}
+
+				var playerScores scores.Response
+				res = do("GET", fmt.Sprintf("/scores?season=1&playerId=%s", playerId), "")
+				_ = json.Unmarshal(res.Body.Bytes(), &playerScores)
+
+				for _, added := range playerScores.Scores {
+					if res = do("PATCH", "/scores/"+added.Id, `{"birdies": 1}`); res.Code != 200 {
+						t.Errorf("expected %d got %d", 200, res.Code)
+					}
+				}
+
+				do("GET", "/scoreboard?season=1", "")
+				do("PUT", "/rules?season=1", `{"season": 1, "birdieMultiplier": 2}`)
+				do("GET", "/members", "")
+				do("GET", "/seasons", "")
+				do("GET", "/halloffame", "")
+			}
+		}(i)
+	}
+
+	// act
+	close(start)
+	wg.Wait()
+
+	// assert
+	res := do("GET", "/scoreboard?season=1", "")
+	if res.Code != 200 {
+		t.Fatalf("expected %d got %d", 200, res.Code)
+	}
+
+	var response scoreboard.Scoreboard
+	_ = json.Unmarshal(res.Body.Bytes(), &response)
+
+	if len(response.Players) != workers {
+		t.Fatalf("expected %d got %d", workers, len(response.Players))
+	}
+
+	for _, p := range response.Players {
+		if p.CountedRounds != roundsPerWorker {
+			t.Errorf("expected %d rounds got %d for %s", roundsPerWorker, p.CountedRounds, p.Name)
+		}
+	}
+}
+
+func TestRequestDeadline(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	database, err := sqlite.Open(t.TempDir() + "/tourleshit.db")
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	defer database.Close()
+
+	srv := httptest.NewServer(server.New(server.Config{
+		MembersRoute:   members.NewMemberRoute(players.NewService(playersDb.NewRepository(database), newAuditService(), utils.NewClock(time.UTC))),
+		RequestTimeout: time.Nanosecond,
+	}).Handler)
+	defer srv.Close()
+
+	request, _ := http.NewRequestWithContext(context.Background(), "GET", srv.URL+"/members", strings.NewReader(""))
+
+	// act
+	res, err := srv.Client().Do(request)
+
+	// assert
+	if err != nil {
+		t.Fatalf("got error: %v expected none", err)
+	}
+
+	expectedStatusCode := 503
+	if res.StatusCode != expectedStatusCode {
+		t.Errorf("expected %d got %d", expectedStatusCode, res.StatusCode)
+	}
+
+	_ = res.Body.Close()
+}
+
+func TestProblemResponses(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func(database *sql.DB, p ...playersModel.Player) *httptest.Server {
+		var playersRepository players.Repository = playersMock.NewRepository(p)
+		if database != nil {
+			playersRepository = playersDb.NewRepository(database)
+		}
+
+		scoreService := score.NewService(scoreMock.NewRepository([]scoreModel.Score{}, playersRepository), players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC)), courses.NewService(coursesMock.NewRepository([]coursesModel.Course{})), seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			MembersRoute: members.NewMemberRoute(players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))),
+			RulesRoute:   rules.NewRulesRoute(scoreService),
+			ScoresRoute:  scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	do := func(t *testing.T, srv *httptest.Server, method, path, body string) server.Problem {
+		t.Helper()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		defer func() { _ = res.Body.Close() }()
+
+		if contentType := res.Header.Get("content-type"); contentType != server.ProblemContentType {
+			t.Errorf("expected %s got %s", server.ProblemContentType, contentType)
+		}
+
+		var problem server.Problem
+		b, _ := io.ReadAll(res.Body)
+		_ = json.Unmarshal(b, &problem)
+
+		if problem.Status != res.StatusCode {
+			t.Errorf("expected status %d in body got %d", res.StatusCode, problem.Status)
+		}
+
+		return problem
+	}
+
+	t.Run("validation problem lists every invalid field", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(nil)
+		defer srv.Close()
+
+		// act
+		problem := do(t, srv, "PUT", "/rules?season=1", `{"season": 1, "birdieMultiplier": -1, "maxEagles": -2}`)
+
+		// assert
+		if problem.Status != 400 || problem.Type != "/problems/validation" || problem.Title != "Bad Request" || problem.Instance != "/rules?season=1" {
+			t.Errorf("expected validation problem of /rules?season=1 got %v", problem)
+		}
+
+		if len(problem.Errors) != 2 || problem.Errors[0].Field != "birdieMultiplier" || problem.Errors[1].Field != "maxEagles" {
+			t.Errorf("expected errors of birdieMultiplier and maxEagles got %v", problem.Errors)
+		}
+	})
+	t.Run("body with a value of the wrong type names the field", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(nil)
+		defer srv.Close()
+
+		// act
+		problem := do(t, srv, "PUT", "/scores", `{"playerId": "1", "points": "ten"}`)
+
+		// assert
+		if problem.Status != 400 || len(problem.Errors) != 1 || problem.Errors[0].Field != "points" {
+			t.Errorf("expected error of points got %v", problem)
+		}
+	})
+	t.Run("duplicate member is a conflict", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(nil, playersModel.Player{Id: "1", Name: "Alice"})
+		defer srv.Close()
+
+		// act
+		problem := do(t, srv, "PUT", "/members", `{"name": "Alice"}`)
+
+		// assert
+		if problem.Status != 409 || problem.Type != "/problems/conflict" || problem.Detail != "name Alice already exists." {
+			t.Errorf("expected conflict got %v", problem)
+		}
+	})
+	t.Run("unsupported method is a 405 with the allowed methods", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(nil)
+		defer srv.Close()
+
+		cases := []struct {
+			method string
+			path   string
+			allow  string
+		}{
+			{method: "POST", path: "/members", allow: "GET, PUT"},
+			{method: "PATCH", path: "/members/1", allow: "GET, POST, DELETE"},
+			{method: "POST", path: "/scores", allow: "GET, PUT"},
+			{method: "PUT", path: "/scores/id1", allow: "GET, PATCH, DELETE"},
+		}
+
+		for _, c := range cases {
+			request, _ := http.NewRequestWithContext(context.Background(), c.method, srv.URL+c.path, strings.NewReader(""))
+
+			// act
+			res, err := srv.Client().Do(request)
+
+			// assert
+			if err != nil {
+				t.Fatalf("got error: %v expected none", err)
+			}
+
+			if res.StatusCode != 405 || res.Header.Get("Allow") != c.allow {
+				t.Errorf("expected %d allowing %s got %d allowing %s", 405, c.allow, res.StatusCode, res.Header.Get("Allow"))
+			}
+
+			if contentType := res.Header.Get("content-type"); contentType != server.ProblemContentType {
+				t.Errorf("expected %s got %s", server.ProblemContentType, contentType)
+			}
+
+			_ = res.Body.Close()
+		}
+	})
+	t.Run("unknown path is not found", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(nil)
+		defer srv.Close()
+
+		// act
+		problem := do(t, srv, "GET", "/unknown", "")
+
+		// assert
+		if problem.Status != 404 || problem.Type != "/problems/not-found" || problem.Instance != "/unknown" {
+			t.Errorf("expected not found problem of /unknown got %v", problem)
+		}
+	})
+	t.Run("unknown error is a 500 without details", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		database, err := sqlite.Open(t.TempDir() + "/tourleshit.db")
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = database.Close()
+
+		srv := beforeEach(database)
+		defer srv.Close()
+
+		// act
+		problem := do(t, srv, "GET", "/members", "")
+
+		// assert
+		if problem.Status != 500 || problem.Type != "about:blank" || problem.Detail != "server error, please contact support" || problem.Instance != "/members" {
+			t.Errorf("expected internal problem got %v", problem)
+		}
+	})
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	beforeEach := func(now func() time.Time, users ...authModel.User) *httptest.Server {
+		playersRepository := playersMock.NewRepository([]playersModel.Player{{Id: "1", Name: "Alice"}})
+		playersService := players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))
+		authService := auth.NewService(authMock.NewRepository(users, playersRepository), playersService, secret, utils.NewClockAt(time.UTC, now))
+
+		cfg := server.Config{
+			Auth:         authService,
+			AuthRoute:    authRoute.NewAuthRoute(authService),
+			MembersRoute: members.NewMemberRoute(playersService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	do := func(t *testing.T, srv *httptest.Server, method, path, token, body string) (*http.Response, []byte) {
+		t.Helper()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		defer func() { _ = res.Body.Close() }()
+
+		b, _ := io.ReadAll(res.Body)
+
+		return res, b
+	}
+
+	// login registers alice as the first user and returns her session token.
+	login := func(t *testing.T, srv *httptest.Server) string {
+		t.Helper()
+
+		res, _ := do(t, srv, "POST", "/auth/users", "", `{"username": "alice", "password": "password1", "playerId": "1"}`)
+		if res.StatusCode != 201 {
+			t.Fatalf("expected %d got %d", 201, res.StatusCode)
+		}
+
+		res, b := do(t, srv, "POST", "/auth/login", "", `{"username": "alice", "password": "password1"}`)
+		if res.StatusCode != 200 {
+			t.Fatalf("expected %d got %d", 200, res.StatusCode)
+		}
+
+		var session authRoute.Session
+		_ = json.Unmarshal(b, &session)
+
+		return session.Token
+	}
+
+	t.Run("request without token return 401", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		// act
+		res, b := do(t, srv, "DELETE", "/members/1", "", "")
+
+		// assert
+		expected := 401
+		if res.StatusCode != expected {
+			t.Fatalf("expected %d got %d", expected, res.StatusCode)
+		}
+
+		if res.Header.Get("WWW-Authenticate") == "" {
+			t.Errorf("expected WWW-Authenticate header got none")
+		}
+
+		var problem server.Problem
+		_ = json.Unmarshal(b, &problem)
+
+		if problem.Type != "/problems/unauthorized" {
+			t.Errorf("expected /problems/unauthorized got %s", problem.Type)
+		}
+	})
+	t.Run("session token of login authenticates the user", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		token := login(t, srv)
+
+		// act
+		res, b := do(t, srv, "GET", "/auth/me", token, "")
+
+		// assert
+		if res.StatusCode != 200 {
+			t.Fatalf("expected %d got %d", 200, res.StatusCode)
+		}
+
+		var me authRoute.User
+		_ = json.Unmarshal(b, &me)
+
+		if me.Username != "alice" || me.PlayerId != "1" {
+			t.Errorf("expected alice playing as 1 got %v", me)
+		}
+
+		res, _ = do(t, srv, "GET", "/members", token, "")
+		if res.StatusCode != 200 {
+			t.Errorf("expected %d got %d", 200, res.StatusCode)
+		}
+	})
+	t.Run("only the first user can be created anonymously", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		token := login(t, srv)
+
+		// act
+		anonymous, _ := do(t, srv, "POST", "/auth/users", "", `{"username": "bob", "password": "password2"}`)
+		authenticated, _ := do(t, srv, "POST", "/auth/users", token, `{"username": "bob", "password": "password2"}`)
+
+		// assert
+		if anonymous.StatusCode != 401 || authenticated.StatusCode != 201 {
+			t.Errorf("expected %d and %d got %d and %d", 401, 201, anonymous.StatusCode, authenticated.StatusCode)
+		}
+	})
+	t.Run("concurrent anonymous requests create a single admin", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		statuses := make(chan int, 5)
+
+		var wg sync.WaitGroup
+
+		// act
+		for i := 0; i < cap(statuses); i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				res, _ := do(t, srv, "POST", "/auth/users", "", fmt.Sprintf(`{"username": "user%d", "password": "password1"}`, i))
+				statuses <- res.StatusCode
+			}(i)
+		}
+
+		wg.Wait()
+		close(statuses)
+
+		// assert
+		created := 0
+
+		for status := range statuses {
+			switch status {
+			case 201:
+				created++
+			case 401:
+			default:
+				t.Errorf("expected %d or %d got %d", 201, 401, status)
+			}
+		}
+
+		if created != 1 {
+			t.Errorf("expected %d got %d", 1, created)
+		}
+	})
+	t.Run("invalid users are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		for _, body := range []string{
+			`{"username": "", "password": "password1"}`,
+			`{"username": "alice", "password": "short"}`,
+			`{"username": "alice", "password": "password1", "playerId": "unknown"}`,
+		} {
+			// act
+			res, _ := do(t, srv, "POST", "/auth/users", "", body)
+
+			// assert
+			if res.StatusCode != 400 {
+				t.Errorf("expected %d got %d for %s", 400, res.StatusCode, body)
+			}
+		}
+	})
+	t.Run("wrong password return 401", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		login(t, srv)
+
+		// act
+		res, _ := do(t, srv, "POST", "/auth/login", "", `{"username": "alice", "password": "password2"}`)
+
+		// assert
+		if res.StatusCode != 401 {
+			t.Errorf("expected %d got %d", 401, res.StatusCode)
+		}
+	})
+	t.Run("tampered or expired session return 401", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		var mu sync.Mutex
+
+		now := time.Now()
+		srv := beforeEach(func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return now
+		})
+		defer srv.Close()
+
+		token := login(t, srv)
+
+		// act
+		tampered, _ := do(t, srv, "GET", "/auth/me", token+"x", "")
+
+		mu.Lock()
+		now = now.Add(auth.SessionDuration)
+		mu.Unlock()
+
+		expired, _ := do(t, srv, "GET", "/auth/me", token, "")
+
+		// assert
+		if tampered.StatusCode != 401 || expired.StatusCode != 401 {
+			t.Errorf("expected %d and %d got %d and %d", 401, 401, tampered.StatusCode, expired.StatusCode)
+		}
+	})
+	t.Run("api token authenticates until revoked", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach(time.Now)
+		defer srv.Close()
+
+		session := login(t, srv)
+
+		res, b := do(t, srv, "POST", "/auth/tokens", session, `{"name": "script"}`)
+		if res.StatusCode != 201 {
+			t.Fatalf("expected %d got %d", 201, res.StatusCode)
+		}
+
+		var created authRoute.ApiToken
+		_ = json.Unmarshal(b, &created)
+
+		// act
+		res, _ = do(t, srv, "GET", "/members", created.Token, "")
+
+		// assert
+		if !strings.HasPrefix(created.Token, auth.ApiTokenPrefix) || res.StatusCode != 200 {
+			t.Fatalf("expected api token to authenticate got %s and %d", created.Token, res.StatusCode)
+		}
+
+		_, b = do(t, srv, "GET", "/auth/tokens", session, "")
+
+		var listed []authRoute.ApiToken
+		_ = json.Unmarshal(b, &listed)
+
+		if len(listed) != 1 || listed[0].Id != created.Id || listed[0].Name != "script" || listed[0].Token != "" {
+			t.Errorf("expected script without its token got %v", listed)
+		}
+
+		res, _ = do(t, srv, "DELETE", "/auth/tokens/"+created.Id, session, "")
+		if res.StatusCode != 204 {
+			t.Errorf("expected %d got %d", 204, res.StatusCode)
+		}
+
+		res, _ = do(t, srv, "GET", "/members", created.Token, "")
+		if res.StatusCode != 401 {
+			t.Errorf("expected %d got %d", 401, res.StatusCode)
+		}
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password1"), bcrypt.MinCost)
+
+	beforeEach := func() *httptest.Server {
+		s := []scoreModel.Score{
+			{Id: "alices-round", PlayerId: "1", PlayerName: "Alice", Season: 1, Day: newDay("2023-05-01")},
+			{Id: "bobs-round", PlayerId: "2", PlayerName: "Bob", Season: 1, Day: newDay("2023-05-01")},
+		}
+		playersRepository := newPlayersRepository(s)
+		playersService := players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))
+		users := []authModel.User{
+			{Id: "1", Username: "admin", PasswordHash: string(hash), Role: authModel.RoleAdmin},
+			{Id: "2", Username: "scorekeeper", PasswordHash: string(hash), Role: authModel.RoleScorekeeper},
+			{Id: "3", Username: "alice", PasswordHash: string(hash), PlayerId: "1", Role: authModel.RolePlayer},
+			{Id: "4", Username: "viewer", PasswordHash: string(hash), Role: authModel.RoleViewer},
+		}
+		authService := auth.NewService(authMock.NewRepository(users, playersRepository), playersService, secret, utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		seasonsService := seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC))
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), playersService, coursesService, seasonsService, newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			Auth:            authService,
+			AuthRoute:       authRoute.NewAuthRoute(authService),
+			MembersRoute:    members.NewMemberRoute(playersService),
+			ScoresRoute:     scores.NewScoresRoute(scoreService),
+			SeasonsRoute:    seasonsRoute.NewSeasonRoute(seasonsService),
+			ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicap.NewService(playersService, scoreService, coursesService)),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	// as returns the status code and problem type of the request made by username.
+	as := func(t *testing.T, srv *httptest.Server, username, method, path, body string) (int, string) {
+		t.Helper()
+
+		login, _ := http.NewRequestWithContext(context.Background(), "POST", srv.URL+"/auth/login", strings.NewReader(`{"username": "`+username+`", "password": "password1"}`))
+
+		res, err := srv.Client().Do(login)
+		if err != nil || res.StatusCode != 200 {
+			t.Fatalf("expected %s to log in got %v", username, err)
+		}
+
+		var session authRoute.Session
+		_ = json.NewDecoder(res.Body).Decode(&session)
+		_ = res.Body.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+session.Token)
+
+		res, err = srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		defer func() { _ = res.Body.Close() }()
+
+		var problem server.Problem
+		_ = json.NewDecoder(res.Body).Decode(&problem)
+
+		return res.StatusCode, problem.Type
+	}
+
+	round := func(playerId string) string {
+		return `{"playerId": "` + playerId + `", "season": 1, "day": "2023-05-02", "points": 20}`
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"viewer reads the scoreboard", "viewer", "GET", "/scoreboard?season=1", "", 200},
+		{"viewer can not enter rounds", "viewer", "PUT", "/scores", round("1"), 403},
+		{"viewer can not add members", "viewer", "POST", "/v2/members", `{"name": "Carol"}`, 403},
+		{"player enters own round", "alice", "PUT", "/scores", round("1"), 201},
+		{"player edits own round", "alice", "PATCH", "/scores/alices-round", `{"points": 30}`, 200},
+		{"player can not enter rounds of others", "alice", "PUT", "/scores", round("2"), 403},
+		{"player can not edit rounds of others", "alice", "PATCH", "/scores/bobs-round", `{"points": 30}`, 403},
+		{"player can not delete rounds of others", "alice", "DELETE", "/scores/bobs-round", "", 403},
+		{"scorekeeper enters rounds of anyone", "scorekeeper", "PUT", "/scores", round("2"), 201},
+		{"scorekeeper edits rounds of anyone", "scorekeeper", "PATCH", "/scores/bobs-round", `{"points": 30}`, 200},
+		{"scorekeeper can not add members", "scorekeeper", "POST", "/v2/members", `{"name": "Carol"}`, 403},
+		{"scorekeeper can not manage seasons", "scorekeeper", "PUT", "/seasons", `{"id": 2, "name": "Season 2", "startDate": "2024-01-01", "endDate": "2024-12-31"}`, 403},
+		{"scorekeeper can not create users", "scorekeeper", "POST", "/auth/users", `{"username": "carol", "password": "password1"}`, 403},
+		{"admin adds members", "admin", "POST", "/v2/members", `{"name": "Carol"}`, 201},
+		{"admin manages seasons", "admin", "PUT", "/seasons", `{"id": 2, "name": "Season 2", "startDate": "2024-01-01", "endDate": "2024-12-31"}`, 200},
+		{"admin creates scorekeepers", "admin", "POST", "/auth/users", `{"username": "carol", "password": "password1", "role": "scorekeeper"}`, 201},
+		{"admin enters rounds of anyone", "admin", "PUT", "/scores", round("2"), 201},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// arrange
+			srv := beforeEach()
+			defer srv.Close()
+
+			// act
+			status, problemType := as(t, srv, tt.username, tt.method, tt.path, tt.body)
+
+			// assert
+			if status != tt.expected {
+				t.Fatalf("expected %d got %d", tt.expected, status)
+			}
+
+			if status == 403 && problemType != "/problems/forbidden" {
+				t.Errorf("expected /problems/forbidden got %s", problemType)
+			}
+		})
+	}
+}
+
+func TestAuditRoute(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password1"), bcrypt.MinCost)
+
+	beforeEach := func() *httptest.Server {
+		s := []scoreModel.Score{{Id: "round", PlayerId: "1", PlayerName: "Alice", Points: 20, Season: 1, Day: newDay("2023-05-01")}}
+		playersRepository := newPlayersRepository(s)
+		auditService := newAuditService()
+		playersService := players.NewService(playersRepository, auditService, utils.NewClock(time.UTC))
+		users := []authModel.User{
+			{Id: "1", Username: "admin", PasswordHash: string(hash), Role: authModel.RoleAdmin},
+			{Id: "2", Username: "scorekeeper", PasswordHash: string(hash), Role: authModel.RoleScorekeeper},
+		}
+		authService := auth.NewService(authMock.NewRepository(users, playersRepository), playersService, secret, utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		seasonsService := seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC))
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), playersService, coursesService, seasonsService, auditService, utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			Auth:         authService,
+			AuditRoute:   auditRoute.NewAuditRoute(auditService),
+			AuthRoute:    authRoute.NewAuthRoute(authService),
+			MembersRoute: members.NewMemberRoute(playersService),
+			ScoresRoute:  scores.NewScoresRoute(scoreService),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	do := func(t *testing.T, srv *httptest.Server, username, method, path, body string) (int, []byte) {
+		t.Helper()
+
+		login, _ := http.NewRequestWithContext(context.Background(), "POST", srv.URL+"/auth/login", strings.NewReader(`{"username": "`+username+`", "password": "password1"}`))
+
+		res, err := srv.Client().Do(login)
+		if err != nil || res.StatusCode != 200 {
+			t.Fatalf("expected %s to log in got %v", username, err)
+		}
+
+		var session authRoute.Session
+		_ = json.NewDecoder(res.Body).Decode(&session)
+		_ = res.Body.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+session.Token)
+
+		res, err = srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		defer func() { _ = res.Body.Close() }()
+
+		b, _ := io.ReadAll(res.Body)
+
+		return res.StatusCode, b
+	}
+
+	getAudit := func(t *testing.T, srv *httptest.Server, query string) []auditRoute.Entry {
+		t.Helper()
+
+		status, b := do(t, srv, "admin", "GET", "/audit"+query, "")
+		if status != 200 {
+			t.Fatalf("expected %d got %d", 200, status)
+		}
+
+		var entries []auditRoute.Entry
+		_ = json.Unmarshal(b, &entries)
+
+		return entries
+	}
+
+	t.Run("every change of members and rounds is recorded with actor and values", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		do(t, srv, "admin", "POST", "/v2/members", `{"name": "Bob"}`)
+		do(t, srv, "scorekeeper", "PATCH", "/scores/round", `{"points": 30}`)
+		do(t, srv, "scorekeeper", "DELETE", "/scores/round", "")
+
+		// assert
+		entries := getAudit(t, srv, "")
+		if len(entries) != 3 {
+			t.Fatalf("expected %d got %d", 3, len(entries))
+		}
+
+		created, updated, deleted := entries[0], entries[1], entries[2]
+
+		var member members.Member
+		_ = json.Unmarshal(created.After, &member)
+
+		if created.Entity != "player" || created.Action != "create" || created.Actor != "admin" || created.Before != nil ||
+			created.EntityId == "" || member.Id != created.EntityId || member.Name != "Bob" || member.Status != "active" {
+			t.Errorf("expected Bob created by admin got %v", created)
+		}
+
+		if updated.Entity != "score" || updated.EntityId != "round" || updated.Action != "update" || updated.Actor != "scorekeeper" ||
+			!strings.Contains(string(updated.Before), `"points":20`) || !strings.Contains(string(updated.After), `"points":30`) {
+			t.Errorf("expected round updated from 20 to 30 points by scorekeeper got %v", updated)
+		}
+
+		if deleted.Action != "delete" || deleted.Actor != "scorekeeper" || !strings.Contains(string(deleted.Before), `"points":30`) || deleted.After != nil {
+			t.Errorf("expected round deleted by scorekeeper got %v", deleted)
+		}
+
+		var round scores.AuditScore
+		_ = json.Unmarshal(updated.After, &round)
+
+		if round.Id != "round" || round.PlayerId != "1" || round.PlayerName != "Alice" || round.Season != 1 {
+			t.Errorf("expected the round of Alice in season 1 got %s", updated.After)
+		}
+
+		for _, internal := range []string{"CreatedAt", "UpdatedAt"} {
+			if strings.Contains(string(updated.After), internal) {
+				t.Errorf("expected the round without timestamps got %s", updated.After)
+			}
+		}
+	})
+	t.Run("added rounds are recorded with their player and season", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		status, _ := do(t, srv, "scorekeeper", "PUT", "/scores", `{"playerId": "1", "points": 10, "season": 1, "day": "2023-05-02"}`)
+		unknown, _ := do(t, srv, "scorekeeper", "PUT", "/scores", `{"playerId": "unknown", "points": 10, "season": 1, "day": "2023-05-02"}`)
+
+		// assert
+		if status != 201 || unknown != 400 {
+			t.Errorf("expected %d and %d got %d and %d", 201, 400, status, unknown)
+		}
+
+		entries := getAudit(t, srv, "?entity=score")
+		if len(entries) != 1 {
+			t.Fatalf("expected %d got %d", 1, len(entries))
+		}
+
+		var round scores.AuditScore
+		_ = json.Unmarshal(entries[0].After, &round)
+
+		if entries[0].Action != "create" || round.PlayerId != "1" || round.PlayerName != "Alice" || round.Season != 1 || round.Points != 10 {
+			t.Errorf("expected the round of Alice in season 1 got %s", entries[0].After)
+		}
+	})
+	t.Run("failed changes are followed by a failed entry", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		status, _ := do(t, srv, "admin", "POST", "/v2/members", `{"name": "Alice"}`)
+
+		// assert
+		if status != 409 {
+			t.Errorf("expected %d got %d", 409, status)
+		}
+
+		entries := getAudit(t, srv, "")
+		if len(entries) != 2 {
+			t.Fatalf("expected %d got %d", 2, len(entries))
+		}
+
+		created, failed := entries[0], entries[1]
+		if created.Action != "create" || failed.Action != "failed" || failed.EntityId != created.EntityId {
+			t.Errorf("expected the create and its failure got %v and %v", created, failed)
+		}
+
+		if string(failed.Before) != string(created.After) || failed.After != nil {
+			t.Errorf("expected the failed entry to revert the create got %s and %s", failed.Before, failed.After)
+		}
+	})
+	t.Run("nothing changes when the change can not be recorded", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		playersRepository := newPlayersRepository(nil)
+		auditService := audit.NewService(failingAuditRepository{auditMock.NewRepository([]auditModel.Entry{})}, utils.NewClock(time.UTC), newAuditRepresentations())
+		cfg := server.Config{
+			MembersRoute: members.NewMemberRoute(players.NewService(playersRepository, auditService, utils.NewClock(time.UTC))),
+		}
+		srv := httptest.NewServer(server.New(cfg).Handler)
+		defer srv.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), "POST", srv.URL+"/v2/members", strings.NewReader(`{"name": "Bob"}`))
+
+		// act
+		res, err := srv.Client().Do(request)
+
+		// assert
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		if res.StatusCode != 500 {
+			t.Errorf("expected %d got %d", 500, res.StatusCode)
+		}
+
+		all, _ := playersRepository.GetPlayers(context.Background())
+		if len(all) != 0 {
+			t.Errorf("expected %d got %d", 0, len(all))
+		}
+	})
+	t.Run("entries are filtered by entity, actor and date", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		do(t, srv, "admin", "POST", "/v2/members", `{"name": "Bob"}`)
+		do(t, srv, "scorekeeper", "DELETE", "/scores/round", "")
+
+		yesterday := utils.FormatDate(time.Now().UTC().AddDate(0, 0, -1))
+		tomorrow := utils.FormatDate(time.Now().UTC().AddDate(0, 0, 1))
+
+		// act
+		byEntity := getAudit(t, srv, "?entity=score")
+		byActor := getAudit(t, srv, "?actor=admin")
+		byDate := getAudit(t, srv, "?from="+yesterday+"&to="+tomorrow)
+		before := getAudit(t, srv, "?to=2000-01-01")
+
+		// assert
+		if len(byEntity) != 1 || byEntity[0].Entity != "score" {
+			t.Errorf("expected the deleted round got %v", byEntity)
+		}
+
+		if len(byActor) != 1 || byActor[0].Actor != "admin" {
+			t.Errorf("expected the created member got %v", byActor)
+		}
+
+		if len(byDate) != 2 || len(before) != 0 {
+			t.Errorf("expected %d and %d got %d and %d", 2, 0, len(byDate), len(before))
+		}
+	})
+	t.Run("invalid filters return 400", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		for _, query := range []string{"?entity=course", "?from=yesterday", "?to=2023-13-01"} {
+			// act
+			status, _ := do(t, srv, "admin", "GET", "/audit"+query, "")
+
+			// assert
+			if status != 400 {
+				t.Errorf("expected %d got %d for %s", 400, status, query)
+			}
+		}
+	})
+}
+
+func TestRestoreRoutes(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		srv   *httptest.Server
+		purge *purge.Job
+	}
+
+	beforeEach := func(retention time.Duration) fixture {
+		s := []scoreModel.Score{{Id: "round", PlayerId: "1", PlayerName: "Alice", Points: 20, Season: 1, Day: newDay("2023-05-01")}}
+		playersRepository := newPlayersRepository(s)
+		playersService := players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		seasonsService := seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(1)}), utils.NewClock(time.UTC))
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), playersService, coursesService, seasonsService, newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			MembersRoute: members.NewMemberRoute(playersService),
+			ScoresRoute:  scores.NewScoresRoute(scoreService),
+		}
+
+		return fixture{
+			srv:   httptest.NewServer(server.New(cfg).Handler),
+			purge: purge.NewJob(playersService, scoreService, retention, utils.NewClock(time.UTC)),
+		}
+	}
+
+	do := func(t *testing.T, srv *httptest.Server, method, path string) int {
+		t.Helper()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(""))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		_ = res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	t.Run("deleted round is hidden until restored", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		f := beforeEach(time.Hour)
+		defer f.srv.Close()
+
+		do(t, f.srv, "DELETE", "/scores/round")
+
+		// act
+		hidden := do(t, f.srv, "GET", "/scores/round")
+		restored := do(t, f.srv, "POST", "/scores/round/restore")
+		again := do(t, f.srv, "POST", "/scores/round/restore")
+
+		// assert
+		if hidden != 404 || restored != 200 || again != 404 {
+			t.Errorf("expected %d, %d and %d got %d, %d and %d", 404, 200, 404, hidden, restored, again)
+		}
+
+		if status := do(t, f.srv, "GET", "/scores/round"); status != 200 {
+			t.Errorf("expected %d got %d", 200, status)
+		}
+	})
+	t.Run("deleted member comes back with their rounds", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		f := beforeEach(time.Hour)
+		defer f.srv.Close()
+
+		do(t, f.srv, "DELETE", "/members/1")
+
+		// act
+		hidden := do(t, f.srv, "GET", "/members/1")
+		restored := do(t, f.srv, "POST", "/members/1/restore")
+		unknown := do(t, f.srv, "POST", "/members/unknown/restore")
+
+		// assert
+		if hidden != 404 || restored != 200 || unknown != 404 {
+			t.Errorf("expected %d, %d and %d got %d, %d and %d", 404, 200, 404, hidden, restored, unknown)
+		}
+
+		if status := do(t, f.srv, "GET", "/scores/round"); status != 200 {
+			t.Errorf("expected %d got %d", 200, status)
+		}
+	})
+	t.Run("purge only removes what was deleted before the retention period", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		kept := beforeEach(time.Hour)
+		defer kept.srv.Close()
+
+		purged := beforeEach(-time.Hour)
+		defer purged.srv.Close()
+
+		do(t, kept.srv, "DELETE", "/members/1")
+		do(t, purged.srv, "DELETE", "/members/1")
+
+		// act
+		keptResult, keptErr := kept.purge.Run(context.Background())
+		purgedResult, purgedErr := purged.purge.Run(context.Background())
+
+		// assert
+		if keptErr != nil || purgedErr != nil {
+			t.Fatalf("got errors: %v and %v expected none", keptErr, purgedErr)
+		}
+
+		if keptResult.Players != 0 || purgedResult.Players != 1 {
+			t.Errorf("expected %d and %d got %d and %d", 0, 1, keptResult.Players, purgedResult.Players)
+		}
+
+		if status := do(t, kept.srv, "POST", "/members/1/restore"); status != 200 {
+			t.Errorf("expected %d got %d", 200, status)
+		}
+
+		if status := do(t, purged.srv, "POST", "/members/1/restore"); status != 404 {
+			t.Errorf("expected %d got %d", 404, status)
+		}
+	})
+}
+
+func TestArchiveMembersRoute(t *testing.T) {
+	t.Parallel()
+
+	beforeEach := func() *httptest.Server {
+		s := []scoreModel.Score{
+			{Id: "past", PlayerId: "1", PlayerName: "Alice", Points: 20, Season: 1, Day: newDay("2022-05-01")},
+			{Id: "current", PlayerId: "2", PlayerName: "Bob", Points: 10, Season: 2, Day: newDay("2023-05-01")},
+		}
+		playersRepository := newPlayersRepository(s)
+		playersService := players.NewService(playersRepository, newAuditService(), utils.NewClock(time.UTC))
+		coursesService := courses.NewService(coursesMock.NewRepository([]coursesModel.Course{}))
+		scoreService := score.NewService(scoreMock.NewRepository(s, playersRepository), playersService, coursesService, seasons.NewService(seasonsMock.NewRepository([]seasonsModel.Season{newOpenSeason(2)}), utils.NewClock(time.UTC)), newAuditService(), utils.NewClock(time.UTC))
+
+		cfg := server.Config{
+			MembersRoute:    members.NewMemberRoute(playersService),
+			ScoreboardRoute: scoreboard.NewScoreboardRoute(scoreService, handicap.NewService(playersService, scoreService, coursesService)),
+		}
+
+		return httptest.NewServer(server.New(cfg).Handler)
+	}
+
+	do := func(t *testing.T, srv *httptest.Server, method, path, body string, v any) int {
+		t.Helper()
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+
+		res, err := srv.Client().Do(request)
+		if err != nil {
+			t.Fatalf("got error: %v expected none", err)
+		}
+
+		defer func() { _ = res.Body.Close() }()
+
+		if v != nil {
+			_ = json.NewDecoder(res.Body).Decode(v)
+		}
+
+		return res.StatusCode
+	}
+
+	t.Run("archived member is only left on past scoreboards", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		status := do(t, srv, "POST", "/members/1/archive", `{"season": 2}`, nil)
+
+		// assert
+		if status != 200 {
+			t.Fatalf("expected %d got %d", 200, status)
+		}
+
+		var all []members.Member
+		do(t, srv, "GET", "/members", "", &all)
+
+		if len(all) != 1 || all[0].Name != "Bob" {
+			t.Errorf("expected Bob got %v", all)
+		}
+
+		var archived members.Member
+		do(t, srv, "GET", "/members/1", "", &archived)
+
+		if archived.Status != "archived" || archived.ArchivedFrom != 2 {
+			t.Errorf("expected Alice archived from season %d got %v", 2, archived)
+		}
+
+		var past, current scoreboard.Scoreboard
+		do(t, srv, "GET", "/scoreboard?season=1", "", &past)
+		do(t, srv, "GET", "/scoreboard?season=2", "", &current)
+
+		if len(past.Players) != 2 || len(current.Players) != 1 || current.Players[0].Id != "2" {
+			t.Errorf("expected %d and %d players got %v and %v", 2, 1, past.Players, current.Players)
+		}
+	})
+	t.Run("unarchived member is back on the current scoreboard", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		do(t, srv, "POST", "/members/1/archive", `{"season": 2}`, nil)
+
+		// act
+		status := do(t, srv, "POST", "/members/1/unarchive", "", nil)
+
+		// assert
+		if status != 200 {
+			t.Fatalf("expected %d got %d", 200, status)
+		}
+
+		var current scoreboard.Scoreboard
+		do(t, srv, "GET", "/scoreboard?season=2", "", &current)
+
+		if len(current.Players) != 2 {
+			t.Errorf("expected %d got %d", 2, len(current.Players))
+		}
+	})
+	t.Run("invalid season returns 400 and unknown member 404", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		srv := beforeEach()
+		defer srv.Close()
+
+		// act
+		invalid := do(t, srv, "POST", "/members/1/archive", `{"season": 0}`, nil)
+		unknown := do(t, srv, "POST", "/members/unknown/archive", `{"season": 2}`, nil)
+
+		// assert
+		if invalid != 400 || unknown != 404 {
+			t.Errorf("expected %d and %d got %d and %d", 400, 404, invalid, unknown)
+		}
+	})
 }